@@ -120,3 +120,34 @@ func BenchmarkDeleteFiveLayers(b *testing.B) {
 		v.Delete("1.2.3.4.5.deleteMe")
 	}
 }
+
+func BenchmarkChangeLargeFixture(b *testing.B) {
+	x := largeFixture()
+
+	for i := 0; i < b.N; i++ {
+		v := NewVoorhees(x)
+		v.Change("users[500].name", "changed")
+	}
+}
+
+func BenchmarkChangeLargeFixtureCopyOnWrite(b *testing.B) {
+	x := largeFixture()
+
+	for i := 0; i < b.N; i++ {
+		v := NewVoorhees(x, CopyOnWrite())
+		v.Change("users[500].name", "changed")
+	}
+}
+
+func largeFixture() map[string]interface{} {
+	users := make([]interface{}, 1000)
+	for i := range users {
+		users[i] = map[string]interface{}{
+			"name":  "jason",
+			"email": "jason@crystal.lake",
+			"tags":  []interface{}{"camp", "lake"},
+		}
+	}
+
+	return map[string]interface{}{"users": users}
+}
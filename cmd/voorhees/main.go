@@ -0,0 +1,213 @@
+// Command voorhees edits JSON documents from the shell, using the same paths as the voorhees package.
+//
+// Usage:
+//
+//	voorhees [-f file] [-i] [-compact] <command> <args>
+//
+// The commands are:
+//
+//	get <path>              print the value at path
+//	add <path> <value>      add value at path, creating any missing nodes
+//	change <path> <value>   change the existing value at path
+//	delete <path>           delete the value at path
+//	move <from> <to>        move the value at from to to
+//
+// Values are parsed as JSON, falling back to a plain string if they are not valid JSON, so both
+// `change user.age 13` and `change user.name jason` behave as expected.
+//
+// The document is read from file, or stdin if no file is provided, and the result is written to stdout, or back
+// to file when -i is set.
+//
+// voorhees exits with status 1 for invalid usage, 2 if the document cannot be parsed, 3 if a path cannot be
+// resolved within the document and 4 if the document cannot be read or the result cannot be written.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sHesl/voorhees"
+)
+
+const (
+	exitUsage     = 1
+	exitParse     = 2
+	exitPathError = 3
+	exitIO        = 4
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("voorhees", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+
+	file := flags.String("f", "", "read the document from `file` rather than stdin")
+	inPlace := flags.Bool("i", false, "write the result back to the file provided by -f, rather than stdout")
+	compact := flags.Bool("compact", false, "write compact rather than indented JSON")
+
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "usage: voorhees [-f file] [-i] [-compact] get|add|change|delete|move <args>")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	if *inPlace && *file == "" {
+		fmt.Fprintln(stderr, "voorhees: -i requires -f")
+		return exitUsage
+	}
+
+	cmd, cmdArgs := flags.Arg(0), flags.Args()
+	if len(cmdArgs) > 0 {
+		cmdArgs = cmdArgs[1:]
+	}
+
+	if expected, known := arity[cmd]; !known || len(cmdArgs) != expected {
+		flags.Usage()
+		return exitUsage
+	}
+
+	var src io.Reader = stdin
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			fmt.Fprintf(stderr, "voorhees: %s\n", err)
+			return exitIO
+		}
+		defer f.Close()
+		src = f
+	}
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		fmt.Fprintf(stderr, "voorhees: unable to read document: %s\n", err)
+		return exitIO
+	}
+
+	doc, err := decodeDocument(bytes.NewReader(data))
+	if err != nil {
+		fmt.Fprintf(stderr, "voorhees: unable to parse document: %s\n", err)
+		return exitParse
+	}
+
+	// the document was decoded solely for this command, so there is no need for NewVoorhees to copy it, which
+	// would also lose the precision of any large numbers
+	v := &voorhees.Voorhees{JSON: doc}
+
+	result, err := execute(v, cmd, cmdArgs)
+	if err != nil {
+		fmt.Fprintf(stderr, "voorhees: %s\n", err)
+		return exitPathError
+	}
+
+	out, err := encode(result, *compact)
+	if err != nil {
+		fmt.Fprintf(stderr, "voorhees: unable to encode result: %s\n", err)
+		return exitParse
+	}
+
+	if *inPlace {
+		info, err := os.Stat(*file)
+		if err == nil {
+			err = os.WriteFile(*file, out, info.Mode())
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "voorhees: %s\n", err)
+			return exitIO
+		}
+		return 0
+	}
+
+	if _, err := stdout.Write(out); err != nil {
+		fmt.Fprintf(stderr, "voorhees: unable to write result: %s\n", err)
+		return exitIO
+	}
+
+	return 0
+}
+
+// arity is the number of arguments expected by each command.
+var arity = map[string]int{
+	"get":    1,
+	"add":    2,
+	"change": 2,
+	"delete": 1,
+	"move":   2,
+}
+
+func execute(v *voorhees.Voorhees, cmd string, args []string) (interface{}, error) {
+	switch cmd {
+	case "get":
+		return v.Get(args[0])
+	case "add":
+		return v.Add(args[0], parseValue(args[1]))
+	case "change":
+		return v.Change(args[0], parseValue(args[1]))
+	case "delete":
+		return v.Delete(args[0])
+	default: // move
+		val, err := v.Get(args[0])
+		if err != nil {
+			return nil, err
+		}
+		if _, err := v.Delete(args[0]); err != nil {
+			return nil, err
+		}
+		return v.Add(args[1], val)
+	}
+}
+
+func decodeDocument(r io.Reader) (map[string]interface{}, error) {
+	d := json.NewDecoder(r)
+	d.UseNumber()
+
+	var doc map[string]interface{}
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	if doc == nil {
+		return nil, fmt.Errorf("document must be a JSON object")
+	}
+
+	return doc, nil
+}
+
+// parseValue parses s as JSON, falling back to treating it as a plain string.
+func parseValue(s string) interface{} {
+	d := json.NewDecoder(bytes.NewReader([]byte(s)))
+	d.UseNumber()
+
+	var val interface{}
+	if err := d.Decode(&val); err != nil || d.More() {
+		return s
+	}
+
+	return val
+}
+
+func encode(x interface{}, compact bool) ([]byte, error) {
+	var out []byte
+	var err error
+
+	if compact {
+		out, err = json.Marshal(x)
+	} else {
+		out, err = json.MarshalIndent(x, "", "  ")
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, '\n'), nil
+}
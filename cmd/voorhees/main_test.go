@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const input = `{"user": {"name": "jason", "id": 12345678901234567890, "tags": ["camp"]}}`
+
+func TestRun(t *testing.T) {
+	type testCase struct {
+		args     []string
+		expected string
+	}
+
+	testCases := []testCase{
+		testCase{
+			[]string{"get", "user.tags[0]"},
+			`"camp"`,
+		},
+		testCase{
+			[]string{"get", "user.id"},
+			`12345678901234567890`,
+		},
+		testCase{
+			[]string{"add", "user.age", "13"},
+			`{"user":{"age":13,"id":12345678901234567890,"name":"jason","tags":["camp"]}}`,
+		},
+		testCase{
+			[]string{"change", "user.name", "pamela"},
+			`{"user":{"id":12345678901234567890,"name":"pamela","tags":["camp"]}}`,
+		},
+		testCase{
+			[]string{"change", "user.tags", `["camp", "lake"]`},
+			`{"user":{"id":12345678901234567890,"name":"jason","tags":["camp","lake"]}}`,
+		},
+		testCase{
+			[]string{"delete", "user.tags[0]"},
+			`{"user":{"id":12345678901234567890,"name":"jason","tags":[]}}`,
+		},
+		testCase{
+			[]string{"move", "user.name", "name"},
+			`{"name":"jason","user":{"id":12345678901234567890,"tags":["camp"]}}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		var stdout, stderr bytes.Buffer
+
+		args := append([]string{"-compact"}, testCase.args...)
+		code := run(args, strings.NewReader(input), &stdout, &stderr)
+
+		assert.Equal(t, 0, code, "Expected %v to succeed: %s", testCase.args, stderr.String())
+		assert.Equal(t, testCase.expected+"\n", stdout.String())
+	}
+}
+
+func TestRunExitCodes(t *testing.T) {
+	type testCase struct {
+		args     []string
+		input    string
+		expected int
+	}
+
+	testCases := []testCase{
+		testCase{[]string{"get"}, input, exitUsage},
+		testCase{[]string{"rename", "a", "b"}, input, exitUsage},
+		testCase{[]string{"-i", "get", "user"}, input, exitUsage},
+		testCase{[]string{"get", "user"}, `{"user":`, exitParse},
+		testCase{[]string{"get", "user"}, `["not", "an", "object"]`, exitParse},
+		testCase{[]string{"get", "user.uhoh"}, input, exitPathError},
+		testCase{[]string{"change", "uhoh.name", "x"}, input, exitPathError},
+		testCase{[]string{"move", "user.uhoh", "name"}, input, exitPathError},
+	}
+
+	for _, testCase := range testCases {
+		var stdout, stderr bytes.Buffer
+
+		code := run(testCase.args, strings.NewReader(testCase.input), &stdout, &stderr)
+
+		assert.Equal(t, testCase.expected, code, "Unexpected exit code for %v", testCase.args)
+		assert.Empty(t, stdout.String())
+		assert.NotEmpty(t, stderr.String())
+	}
+}
+
+func TestRunIOErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	type testCase struct {
+		args   []string
+		stdout io.Writer
+	}
+
+	testCases := []testCase{
+		testCase{[]string{"-f", filepath.Join(dir, "missing.json"), "get", "user"}, &bytes.Buffer{}},
+		testCase{[]string{"-f", dir, "get", "user"}, &bytes.Buffer{}},
+		testCase{[]string{"get", "user"}, failingWriter{}},
+	}
+
+	for _, testCase := range testCases {
+		var stderr bytes.Buffer
+
+		code := run(testCase.args, strings.NewReader(input), testCase.stdout, &stderr)
+
+		assert.Equal(t, exitIO, code, "Unexpected exit code for %v", testCase.args)
+		assert.NotEmpty(t, stderr.String())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestRunInPlace(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "doc.json")
+	assert.NoError(t, os.WriteFile(file, []byte(input), 0600))
+
+	var stdout, stderr bytes.Buffer
+	code := run([]string{"-f", file, "-i", "delete", "user.tags"}, nil, &stdout, &stderr)
+
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stdout.String())
+
+	result, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\n  \"user\": {\n    \"id\": 12345678901234567890,\n    \"name\": \"jason\"\n  }\n}\n", string(result))
+}
@@ -0,0 +1,134 @@
+package voorhees
+
+import (
+	"reflect"
+)
+
+// CopyOnWrite avoids deep copying the document passed to NewVoorhees. Instead, the source document is shared, and
+// only the maps and arrays along the path of each manipulation are copied, the first time they are modified.
+// The source document is still never modified by the Voorhees instance, but because it is shared:
+//   - any values inside the source that are not JSON types (i.e int rather than float64) are left as they are;
+//   - modifying the source document, or the nodes inside the results returned, will affect each other.
+func CopyOnWrite() Option {
+	return func(v *Voorhees) {
+		v.cow = true
+	}
+}
+
+// prepare readies x to be navigated into, converting maps and arrays of any type to map[string]interface{} and
+// []interface{}. When mutating in CopyOnWrite mode, nodes not yet owned by v are copied so the source document
+// is never modified. When mutating, the result must always be written back in place of x.
+func (v *Voorhees) prepare(x interface{}, mutating bool) interface{} {
+	if mutating && v.cow && !v.owns(x) {
+		copied := shallowCopy(x)
+		v.own(copied)
+		return copied
+	}
+
+	if m, isMap := asMap(x); isMap {
+		return m
+	}
+
+	if a, isArray := asArray(x); isArray {
+		return a
+	}
+
+	return x
+}
+
+// own records that node was created by v, and is therefore safe to modify in CopyOnWrite mode.
+func (v *Voorhees) own(node interface{}) {
+	if !v.cow {
+		return
+	}
+
+	if ptr := nodePointer(node); ptr != 0 {
+		if v.owned == nil {
+			v.owned = make(map[uintptr]interface{})
+		}
+		if len(v.owned) >= v.sweepAt {
+			v.sweep()
+		}
+		v.owned[ptr] = node // keeps node reachable, so its address can't be reused by a node v doesn't own
+	}
+}
+
+// minSweep is the number of owned nodes below which sweep is never run.
+const minSweep = 64
+
+// sweep forgets the owned nodes that are no longer part of the document, so they can be garbage collected. It runs
+// whenever the number of owned nodes has doubled since the last sweep, so costs no more than copying them did.
+// Forgetting a node is always safe: one that is still being attached to the document is simply copied again the
+// next time it is modified.
+func (v *Voorhees) sweep() {
+	live := make(map[uintptr]interface{})
+
+	var visit func(node interface{})
+	visit = func(node interface{}) {
+		ptr := nodePointer(node)
+		if _, owned := v.owned[ptr]; !owned {
+			return // any nodes beneath one v doesn't own are copied along with it before being modified
+		}
+		live[ptr] = node
+
+		switch n := node.(type) {
+		case map[string]interface{}:
+			for _, child := range n {
+				visit(child)
+			}
+		case []interface{}:
+			for _, child := range n {
+				visit(child)
+			}
+		}
+	}
+
+	visit(v.JSON)
+
+	v.owned = live
+	v.sweepAt = 2*len(live) + minSweep
+}
+
+func (v *Voorhees) owns(node interface{}) bool {
+	_, owned := v.owned[nodePointer(node)]
+	return owned
+}
+
+// nodePointer identifies a map or non-empty array by the address of its underlying data.
+func nodePointer(node interface{}) uintptr {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		return reflect.ValueOf(n).Pointer()
+	case []interface{}:
+		if len(n) > 0 {
+			return reflect.ValueOf(n).Pointer()
+		}
+	}
+
+	return 0
+}
+
+// shallowCopy copies a map or array (of any type) into a new map[string]interface{} or []interface{}, sharing the
+// values inside it. Any other value is returned as it is.
+func shallowCopy(x interface{}) interface{} {
+	switch node := x.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(node))
+		for k, val := range node {
+			copied[k] = val
+		}
+		return copied
+	case []interface{}:
+		return append([]interface{}{}, node...)
+	}
+
+	if m, isMap := asMap(x); isMap {
+		return m // asMap has already built a new map
+	}
+
+	if a, isArray := asArray(x); isArray {
+		return a
+	}
+
+	return x
+}
@@ -0,0 +1,124 @@
+package voorhees
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCopyOnWrite(t *testing.T) {
+	input := copyInput(cowInput)
+
+	v := NewVoorhees(input, CopyOnWrite())
+	_, err := v.Apply(
+		Operation{OpChange, "layer1.changeMe", "changed"},
+		Operation{Op: OpDelete, Path: "layer1.array[0].deleteMe"},
+		Operation{OpAdd, "layer1.array[0].added", "excellent"},
+		Operation{OpChange, "layer1.matrix[0][1]", "changed"},
+		Operation{OpAdd, "layer1.matrix[1][0]", "added"},
+		Operation{Op: OpDelete, Path: "keepMe"},
+		Operation{OpAdd, "layer2.added", "excellent"},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, cowInput, input, "Expected CopyOnWrite to never modify the source document")
+
+	expected := map[string]interface{}{
+		"shared": map[string]interface{}{
+			"untouched": []interface{}{"a"},
+		},
+		"layer1": map[string]interface{}{
+			"changeMe": "changed",
+			"array": []interface{}{
+				map[string]interface{}{"added": "excellent"},
+			},
+			"matrix": []interface{}{
+				[]interface{}{"a", "changed"},
+				[]interface{}{"added"},
+			},
+		},
+		"layer2": map[string]interface{}{
+			"added": "excellent",
+		},
+	}
+
+	assert.Equal(t, expected, v.JSON)
+
+	shared := reflect.ValueOf(v.JSON["shared"]).Pointer()
+	assert.Equal(t, reflect.ValueOf(input["shared"]).Pointer(), shared, "Expected untouched nodes to be shared")
+}
+
+func TestCopyOnWriteOnlyCopiesOnce(t *testing.T) {
+	v := NewVoorhees(copyInput(cowInput), CopyOnWrite())
+
+	v.Add("layer1.first", 1)
+	layer1 := reflect.ValueOf(v.JSON["layer1"]).Pointer()
+	v.Add("layer1.second", 2)
+
+	assert.Equal(t, layer1, reflect.ValueOf(v.JSON["layer1"]).Pointer())
+}
+
+func TestCopyOnWriteGetDoesNotCopy(t *testing.T) {
+	input := copyInput(cowInput)
+	v := NewVoorhees(input, CopyOnWrite())
+
+	val, err := v.Get("layer1.array[0].deleteMe")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "please", val)
+	assert.Equal(t, reflect.ValueOf(input["layer1"]).Pointer(), reflect.ValueOf(v.JSON["layer1"]).Pointer())
+	assert.Len(t, v.owned, 1, "Expected only the root to have been copied")
+}
+
+func TestCopyOnWriteMatchesDeepCopy(t *testing.T) {
+	input := deepCopy(cowInput)
+
+	for seed := int64(0); seed < 20; seed++ {
+		ops := RandomOperations(input, seed, 10)
+
+		expected, err := NewVoorhees(input).Apply(ops...)
+		assert.NoError(t, err)
+
+		result, err := NewVoorhees(input, CopyOnWrite()).Apply(ops...)
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	}
+
+	assert.Equal(t, deepCopy(cowInput), input)
+}
+
+func TestCopyOnWriteDoesNotOwnRecycledNodes(t *testing.T) {
+	v := NewVoorhees(copyInput(cowInput), CopyOnWrite())
+
+	for i := 0; i < 100; i++ {
+		v.Add("owned", map[string]interface{}{})
+		v.Add("owned.prop", i) // copies the map, so the copy is owned by v
+		v.Delete("owned")
+	}
+	runtime.GC()
+
+	for i := 0; i < 10000; i++ {
+		external := map[string]interface{}{}
+		if !assert.False(t, v.owns(external), "Expected a map allocated after an owned map was dropped not to be owned") {
+			return
+		}
+	}
+}
+
+func TestCopyOnWriteForgetsRemovedNodes(t *testing.T) {
+	v := NewVoorhees(copyInput(cowInput), CopyOnWrite())
+
+	for i := 0; i < 1000; i++ {
+		v.Add("owned.nested[0].prop", i)
+		v.Change("owned", nil)
+		v.Delete("owned")
+	}
+
+	assert.True(t, len(v.owned) <= 2*minSweep, "Expected removed nodes to be forgotten, but %d are owned", len(v.owned))
+
+	v.Add("layer1.array[0].prop", true)
+	assert.True(t, v.owns(v.JSON["layer1"]), "Expected nodes still in the document to remain owned")
+}
@@ -0,0 +1,287 @@
+package voorhees
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+)
+
+// Difference describes a single node that differs between two documents, identified by its path.
+type Difference struct {
+	Path string
+	A    interface{}
+	B    interface{}
+
+	// MissingFromA and MissingFromB are set when the node is only present in one of the documents.
+	MissingFromA bool
+	MissingFromB bool
+}
+
+// String describes the difference, i.e `layer1.prop: "a" != "b"`.
+func (d Difference) String() string {
+	a, b := describeValue(d.A), describeValue(d.B)
+
+	if d.MissingFromA {
+		a = "<missing>"
+	}
+	if d.MissingFromB {
+		b = "<missing>"
+	}
+
+	return fmt.Sprintf("%s: %s != %s", d.Path, a, b)
+}
+
+// Diff compares a and b, returning a Difference for every node that is not equal in both, with paths relative to
+// a and b. Numbers are compared by value, regardless of their type, so int(1), float64(1) and json.Number("1")
+// are all equal. Any kind of slice or map with string keys is compared as its JSON equivalent.
+func Diff(a, b interface{}) []Difference {
+	_, diffs := Equal(a, b)
+
+	return diffs
+}
+
+// EqualOption customises the comparison made by Equal.
+type EqualOption func(*differ)
+
+// IgnorePaths excludes the nodes at each of the provided paths, and everything beneath them, from the comparison.
+// Paths may contain wildcards, where * matches any property and [*] any array index, i.e items[*].id.
+// An error is returned if any of the paths are invalid.
+func IgnorePaths(paths ...string) (EqualOption, error) {
+	patterns := make([]pathPattern, len(paths))
+
+	for i, path := range paths {
+		pattern, err := compilePattern(path)
+		if err != nil {
+			return nil, fmt.Errorf("[Voorhees]: Invalid path %s passed to IgnorePaths: %s", path, err)
+		}
+		patterns[i] = pattern
+	}
+
+	return func(d *differ) {
+		d.ignore = append(d.ignore, patterns...)
+	}, nil
+}
+
+// IgnoreArrayOrder treats arrays as equal if they contain equal elements, regardless of the order of those elements.
+// Elements without an equal counterpart are reported at their index in whichever document contains them.
+func IgnoreArrayOrder() EqualOption {
+	return func(d *differ) {
+		d.ignoreArrayOrder = true
+	}
+}
+
+// Equal compares a and b in the same way as Diff, subject to any opts, reporting whether they are equal alongside
+// every Difference found.
+func Equal(a, b interface{}, opts ...EqualOption) (bool, []Difference) {
+	d := &differ{}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	diffs := d.diff("", a, b)
+
+	return len(diffs) == 0, diffs
+}
+
+type differ struct {
+	ignore           []pathPattern
+	ignoreArrayOrder bool
+}
+
+func (d *differ) ignored(path string) bool {
+	for _, pattern := range d.ignore {
+		if pattern.matches(path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (d *differ) diff(path string, a, b interface{}) []Difference {
+	if path != "" && d.ignored(path) {
+		return nil
+	}
+
+	aMap, aIsMap := asMap(a)
+	bMap, bIsMap := asMap(b)
+
+	if aIsMap && bIsMap {
+		var diffs []Difference
+
+		for _, k := range unionOfKeys(aMap, bMap) {
+			aVal, inA := aMap[k]
+			bVal, inB := bMap[k]
+
+			if inA && inB {
+				diffs = append(diffs, d.diff(joinPath(path, k), aVal, bVal)...)
+			} else if !d.ignored(joinPath(path, k)) {
+				diffs = append(diffs, Difference{joinPath(path, k), aVal, bVal, !inA, !inB})
+			}
+		}
+
+		return diffs
+	}
+
+	aArray, aIsArray := asArray(a)
+	bArray, bIsArray := asArray(b)
+
+	if aIsArray && bIsArray {
+		if d.ignoreArrayOrder {
+			return d.diffUnordered(path, aArray, bArray)
+		}
+
+		var diffs []Difference
+
+		for i := 0; i < len(aArray) || i < len(bArray); i++ {
+			switch {
+			case i < len(aArray) && i < len(bArray):
+				diffs = append(diffs, d.diff(indexPath(path, i), aArray[i], bArray[i])...)
+			case d.ignored(indexPath(path, i)):
+			case i < len(aArray):
+				diffs = append(diffs, Difference{Path: indexPath(path, i), A: aArray[i], MissingFromB: true})
+			default:
+				diffs = append(diffs, Difference{Path: indexPath(path, i), B: bArray[i], MissingFromA: true})
+			}
+		}
+
+		return diffs
+	}
+
+	if !valuesEqual(a, b) {
+		return []Difference{Difference{Path: path, A: a, B: b}}
+	}
+
+	return nil
+}
+
+// diffUnordered pairs each element of a with the first unpaired, equal element of b, reporting any elements
+// that are left without a partner.
+func (d *differ) diffUnordered(path string, a, b []interface{}) []Difference {
+	var diffs []Difference
+	paired := make([]bool, len(b))
+
+	for i := range a {
+		found := false
+
+		for j := range b {
+			if !paired[j] && len(d.diff(indexPath(path, i), a[i], b[j])) == 0 {
+				paired[j], found = true, true
+				break
+			}
+		}
+
+		if !found && !d.ignored(indexPath(path, i)) {
+			diffs = append(diffs, Difference{Path: indexPath(path, i), A: a[i], MissingFromB: true})
+		}
+	}
+
+	for j := range b {
+		if !paired[j] && !d.ignored(indexPath(path, j)) {
+			diffs = append(diffs, Difference{Path: indexPath(path, j), B: b[j], MissingFromA: true})
+		}
+	}
+
+	return diffs
+}
+
+func valuesEqual(a, b interface{}) bool {
+	aNum, aIsNum := asNumber(a)
+	bNum, bIsNum := asNumber(b)
+
+	if aIsNum && bIsNum {
+		return aNum.Cmp(bNum) == 0
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+// asNumber converts any Go numeric type, or a json.Number, into an exact representation of its value.
+func asNumber(x interface{}) (*big.Rat, bool) {
+	if n, isNumber := x.(json.Number); isNumber {
+		return new(big.Rat).SetString(string(n))
+	}
+
+	val := reflect.ValueOf(x)
+
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return new(big.Rat).SetInt64(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return new(big.Rat).SetUint64(val.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := val.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, false
+		}
+		return new(big.Rat).SetFloat64(f), true
+	}
+
+	return nil, false
+}
+
+// asMap returns x as a map[string]interface{}, converting any other type of map with string keys.
+func asMap(x interface{}) (map[string]interface{}, bool) {
+	if m, isMap := x.(map[string]interface{}); isMap {
+		return m, true
+	}
+
+	val := reflect.ValueOf(x)
+	if val.Kind() != reflect.Map || val.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+
+	m := make(map[string]interface{}, val.Len())
+	for _, k := range val.MapKeys() {
+		m[k.String()] = val.MapIndex(k).Interface()
+	}
+
+	return m, true
+}
+
+// asArray returns x as a []interface{}, converting any other type of slice or array.
+func asArray(x interface{}) ([]interface{}, bool) {
+	if a, isArray := x.([]interface{}); isArray {
+		return a, true
+	}
+
+	val := reflect.ValueOf(x)
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return nil, false
+	}
+
+	a := make([]interface{}, val.Len())
+	for i := range a {
+		a[i] = val.Index(i).Interface()
+	}
+
+	return a, true
+}
+
+func unionOfKeys(a, b map[string]interface{}) []string {
+	keys := make([]string, 0, len(a)+len(b))
+
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, inA := a[k]; !inA {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// describeValue formats x as JSON where possible, so strings are quoted and nodes are readable.
+func describeValue(x interface{}) string {
+	if bytes, err := json.Marshal(x); err == nil {
+		return string(bytes)
+	}
+
+	return fmt.Sprintf("%v", x)
+}
@@ -0,0 +1,142 @@
+package voorhees
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	a := map[string]interface{}{
+		"same":    "please",
+		"changed": "a",
+		"onlyA":   true,
+		"numbers": map[string]interface{}{
+			"int":     1,
+			"float":   float64(2),
+			"number":  json.Number("3.0"),
+			"differs": 4,
+		},
+		"array": []map[string]interface{}{
+			map[string]interface{}{"prop": "a"},
+			map[string]interface{}{"prop": "b"},
+		},
+	}
+
+	b := map[string]interface{}{
+		"same":    "please",
+		"changed": "b",
+		"onlyB":   nil,
+		"numbers": map[string]interface{}{
+			"int":     float64(1),
+			"float":   int64(2),
+			"number":  3,
+			"differs": 4.5,
+		},
+		"array": []interface{}{
+			map[string]interface{}{"prop": "a"},
+		},
+	}
+
+	expected := []Difference{
+		Difference{Path: "array[1]", A: map[string]interface{}{"prop": "b"}, MissingFromB: true},
+		Difference{Path: "changed", A: "a", B: "b"},
+		Difference{Path: "numbers.differs", A: 4, B: 4.5},
+		Difference{Path: "onlyA", A: true, MissingFromB: true},
+		Difference{Path: "onlyB", B: nil, MissingFromA: true},
+	}
+
+	assert.Equal(t, expected, Diff(a, b))
+	assert.Empty(t, Diff(a, a))
+}
+
+func TestDiffPathsAreAddressable(t *testing.T) {
+	a := map[string]interface{}{"a.b": []interface{}{[]interface{}{"c"}}}
+	b := map[string]interface{}{"a.b": []interface{}{[]interface{}{"d"}}}
+
+	diffs := Diff(a, b)
+
+	assert.Len(t, diffs, 1)
+
+	val, err := NewVoorhees(a).Get(diffs[0].Path)
+	assert.NoError(t, err)
+	assert.Equal(t, "c", val)
+}
+
+func TestDifferenceString(t *testing.T) {
+	assert.Equal(t, `a.b: "x" != 2`, Difference{Path: "a.b", A: "x", B: 2}.String())
+	assert.Equal(t, `a[1]: <missing> != {"c":true}`,
+		Difference{Path: "a[1]", B: map[string]interface{}{"c": true}, MissingFromA: true}.String())
+}
+
+func TestEqualIgnorePaths(t *testing.T) {
+	a := map[string]interface{}{
+		"meta": map[string]interface{}{
+			"timestamp": "2018-01-01",
+			"version":   1,
+		},
+		"items": []interface{}{
+			map[string]interface{}{"id": "a", "price": 1},
+			map[string]interface{}{"id": "b", "price": 2},
+		},
+		"extra": map[string]interface{}{"nested": true},
+	}
+
+	b := map[string]interface{}{
+		"meta": map[string]interface{}{
+			"timestamp": "2019-01-01",
+			"version":   float64(1),
+		},
+		"items": []interface{}{
+			map[string]interface{}{"id": "c", "price": 1},
+			map[string]interface{}{"id": "d", "price": 3},
+		},
+	}
+
+	ignore, err := IgnorePaths("meta.timestamp", "items[*].id", "extra")
+	assert.NoError(t, err)
+
+	equal, diffs := Equal(a, b, ignore)
+
+	assert.False(t, equal)
+	assert.Equal(t, []Difference{Difference{Path: "items[1].price", A: 2, B: 3}}, diffs)
+
+	ignore, err = IgnorePaths("meta.timestamp", "items[*].id", "items[*].price", "extra")
+	assert.NoError(t, err)
+
+	equal, diffs = Equal(a, b, ignore)
+
+	assert.True(t, equal)
+	assert.Empty(t, diffs)
+}
+
+func TestEqualIgnoreArrayOrder(t *testing.T) {
+	a := map[string]interface{}{
+		"tags":  []interface{}{"a", "b", "c"},
+		"items": []interface{}{map[string]interface{}{"id": 1}, map[string]interface{}{"id": 2}},
+	}
+
+	b := map[string]interface{}{
+		"tags":  []interface{}{"c", "a", "d"},
+		"items": []interface{}{map[string]interface{}{"id": 2.0}, map[string]interface{}{"id": 1.0}},
+	}
+
+	equal, diffs := Equal(a, b, IgnoreArrayOrder())
+
+	assert.False(t, equal)
+	assert.Equal(t, []Difference{
+		Difference{Path: "tags[1]", A: "b", MissingFromB: true},
+		Difference{Path: "tags[2]", B: "d", MissingFromA: true},
+	}, diffs)
+
+	assert.Len(t, Diff(a, b), 5)
+}
+
+func TestIgnorePathsInvalidPath(t *testing.T) {
+	ignore, err := IgnorePaths("meta.timestamp", "items[x]")
+
+	assert.Nil(t, ignore)
+	assert.EqualError(t, err, "[Voorhees]: Invalid path items[x] passed to IgnorePaths: "+
+		"[Voorhees]: Array Path | items[x] is not a valid array denotion")
+}
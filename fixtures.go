@@ -0,0 +1,259 @@
+package voorhees
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Fixture is a named document, produced by applying one of the cases of a fixture file to its base.
+type Fixture struct {
+	Name string
+	JSON map[string]interface{}
+}
+
+// FixtureError describes a problem with a fixture file, pinpointing the line responsible.
+type FixtureError struct {
+	File string
+	Line int
+	Case string
+	Err  error
+}
+
+func (e *FixtureError) Error() string {
+	if e.Case == "" {
+		return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Err)
+	}
+
+	return fmt.Sprintf("%s:%d: case %q: %s", e.File, e.Line, e.Case, e.Err)
+}
+
+// FixtureErrors aggregates a FixtureError for every case of a fixture file that could not be applied.
+type FixtureErrors []*FixtureError
+
+func (e FixtureErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+// LoadFixtures reads the fixture file at filename. See ReadFixtures for the format of fixture files.
+func LoadFixtures(filename string) ([]Fixture, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadFixtures(f, filename)
+}
+
+// ReadFixtures reads a fixture file from r, applying each of its cases to a fresh copy of its base document.
+// Fixture files are JSON, describing a base document and a list of named cases, each a list of operations:
+//
+//	{
+//	  "base": {"user": {"name": "jason", "email": "jason@crystal.lake"}},
+//	  "cases": [
+//	    {"name": "without email", "ops": [{"op": "delete", "path": "user.email"}]},
+//	    {"name": "renamed", "ops": [{"op": "change", "path": "user.name", "value": "pamela"}]}
+//	  ]
+//	}
+//
+// Cases that fail to apply are reported together as FixtureErrors, each naming the file and line of the failing
+// operation, alongside the fixtures that were applied successfully. filename is used only for error messages.
+// Fixture files written in YAML can be read with the voorheesyaml package.
+func ReadFixtures(r io.Reader, filename string) ([]Fixture, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &fixtureParser{data: data, file: filename, dec: json.NewDecoder(bytes.NewReader(data))}
+
+	base, cases, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures []Fixture
+	var errs FixtureErrors
+
+	for _, c := range cases {
+		v := NewVoorhees(base)
+		var failed *FixtureError
+
+		for i, op := range c.ops {
+			if err := v.apply(op); err != nil {
+				failed = &FixtureError{filename, c.opLines[i], c.name, err}
+				break
+			}
+		}
+
+		if failed != nil {
+			errs = append(errs, failed)
+			continue
+		}
+
+		fixtures = append(fixtures, Fixture{c.name, v.JSON})
+	}
+
+	if len(errs) > 0 {
+		return fixtures, errs
+	}
+
+	return fixtures, nil
+}
+
+type fixtureCase struct {
+	name    string
+	ops     []Operation
+	opLines []int
+}
+
+// fixtureParser walks the tokens of a fixture file, so that the line of each operation can be recorded.
+type fixtureParser struct {
+	data []byte
+	file string
+	dec  *json.Decoder
+}
+
+func (p *fixtureParser) parse() (map[string]interface{}, []fixtureCase, error) {
+	base := map[string]interface{}{}
+	var cases []fixtureCase
+
+	err := p.object(func(key string) error {
+		switch key {
+		case "base":
+			return p.decode(&base)
+		case "cases":
+			return p.array(func() error {
+				c, err := p.fixtureCase()
+				cases = append(cases, c)
+				return err
+			})
+		default:
+			return p.decode(&json.RawMessage{})
+		}
+	})
+
+	if err != nil {
+		return nil, nil, p.wrap(err)
+	}
+
+	return base, cases, nil
+}
+
+func (p *fixtureParser) fixtureCase() (fixtureCase, error) {
+	var c fixtureCase
+
+	err := p.object(func(key string) error {
+		switch key {
+		case "name":
+			return p.decode(&c.name)
+		case "ops":
+			return p.array(func() error {
+				var op Operation
+				c.opLines = append(c.opLines, p.line())
+				err := p.decode(&op)
+				c.ops = append(c.ops, op)
+				return err
+			})
+		default:
+			return p.decode(&json.RawMessage{})
+		}
+	})
+
+	return c, err
+}
+
+func (p *fixtureParser) object(field func(key string) error) error {
+	if err := p.delim('{'); err != nil {
+		return err
+	}
+
+	for p.dec.More() {
+		token, err := p.dec.Token()
+		if err != nil {
+			return err
+		}
+
+		if err := field(token.(string)); err != nil {
+			return err
+		}
+	}
+
+	return p.delim('}')
+}
+
+func (p *fixtureParser) array(elem func() error) error {
+	if err := p.delim('['); err != nil {
+		return err
+	}
+
+	for p.dec.More() {
+		if err := elem(); err != nil {
+			return err
+		}
+	}
+
+	return p.delim(']')
+}
+
+func (p *fixtureParser) delim(expected json.Delim) error {
+	line := p.line()
+
+	token, err := p.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != expected {
+		return &FixtureError{File: p.file, Line: line, Err: fmt.Errorf("expected %s but found %v", expected, token)}
+	}
+
+	return nil
+}
+
+// decode decodes the next value into x, attributing any type errors to the line the value starts on.
+func (p *fixtureParser) decode(x interface{}) error {
+	line := p.line()
+
+	err := p.dec.Decode(x)
+	if _, isTypeErr := err.(*json.UnmarshalTypeError); isTypeErr {
+		return &FixtureError{File: p.file, Line: line, Err: err}
+	}
+
+	return err
+}
+
+// line returns the line of the next token to be decoded.
+func (p *fixtureParser) line() int {
+	return lineAt(p.data, p.dec.InputOffset())
+}
+
+// wrap ensures err carries the file and line it occurred on.
+func (p *fixtureParser) wrap(err error) error {
+	switch e := err.(type) {
+	case *FixtureError:
+		return e
+	case *json.SyntaxError:
+		return &FixtureError{File: p.file, Line: lineAt(p.data, e.Offset), Err: err}
+	}
+
+	return &FixtureError{File: p.file, Line: p.line(), Err: err}
+}
+
+// lineAt returns the line of the first token at or after offset, skipping any whitespace and separators.
+func lineAt(data []byte, offset int64) int {
+	for offset < int64(len(data)) && strings.IndexByte(" \t\r\n,:", data[offset]) != -1 {
+		offset++
+	}
+
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
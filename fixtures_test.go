@@ -0,0 +1,67 @@
+package voorhees
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFixtures(t *testing.T) {
+	expected := []Fixture{
+		Fixture{"without email", map[string]interface{}{
+			"user": map[string]interface{}{
+				"name": "jason",
+				"tags": []interface{}{"camp"},
+			},
+		}},
+		Fixture{"renamed and tagged", map[string]interface{}{
+			"user": map[string]interface{}{
+				"name":  "pamela",
+				"email": "jason@crystal.lake",
+				"tags":  []interface{}{"camp", "mother"},
+			},
+		}},
+	}
+
+	expectedErr := `testdata/fixtures.json:27: case "missing property": ` +
+		`[Voorhees]: Unable to change uhoh because it doesn't exist at path user` + "\n" +
+		`testdata/fixtures.json:33: case "unknown operation": [Voorhees]: Unknown operation move for path user.name`
+
+	fixtures, err := LoadFixtures("testdata/fixtures.json")
+
+	assert.Equal(t, expected, fixtures)
+	assert.Error(t, err)
+	assert.Equal(t, expectedErr, err.Error())
+	assert.Len(t, err.(FixtureErrors), 2)
+}
+
+func TestReadFixturesInvalidJSON(t *testing.T) {
+	type testCase struct {
+		input    string
+		expected string
+	}
+
+	testCases := []testCase{
+		testCase{
+			"{\n  \"cases\": [\n    {\"name\": \"a\", \"ops\": [{\"op\": 1}]}\n  ]\n}",
+			"fixtures.json:3: json: cannot unmarshal number into Go struct field Operation.op of type string",
+		},
+		testCase{
+			"{\n  \"cases\": {}\n}",
+			"fixtures.json:2: expected [ but found {",
+		},
+		testCase{
+			"{\n  \"base\": {,}\n}",
+			"fixtures.json:2: invalid character ',' looking for beginning of object key string",
+		},
+	}
+
+	for _, testCase := range testCases {
+		fixtures, err := ReadFixtures(strings.NewReader(testCase.input), "fixtures.json")
+
+		assert.Nil(t, fixtures)
+		assert.Error(t, err)
+		assert.Equal(t, testCase.expected, err.Error())
+	}
+}
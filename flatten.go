@@ -0,0 +1,49 @@
+package voorhees
+
+import (
+	"sort"
+)
+
+// Flatten returns every leaf of the document keyed by its path, i.e {"a":{"b":[1]}} becomes {"a.b[0]":1}.
+// Empty maps and arrays are leaves in their own right, so that no information is lost when the result is passed
+// to Unflatten.
+func (v *Voorhees) Flatten() map[string]interface{} {
+	flat := make(map[string]interface{})
+
+	v.Walk(func(path string, value interface{}) error {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			if len(node) == 0 {
+				flat[path] = map[string]interface{}{}
+			}
+		case []interface{}:
+			if len(node) == 0 {
+				flat[path] = []interface{}{}
+			}
+		default:
+			flat[path] = value
+		}
+		return nil
+	})
+
+	return flat
+}
+
+// Unflatten rebuilds a nested document from a map of paths to values, such as the one produced by Flatten.
+// Each path is applied in sorted order via Add, so any nodes or arrays that do not exist are created along the way.
+func Unflatten(flat map[string]interface{}) (map[string]interface{}, error) {
+	paths := make([]string, 0, len(flat))
+	for path := range flat {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	v := &Voorhees{JSON: map[string]interface{}{}}
+	for _, path := range paths {
+		if _, err := v.Add(path, flat[path]); err != nil {
+			return nil, err
+		}
+	}
+
+	return v.JSON, nil
+}
@@ -0,0 +1,103 @@
+package voorhees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlatten(t *testing.T) {
+	input := map[string]interface{}{
+		"db": map[string]interface{}{
+			"primary": map[string]interface{}{
+				"host": "localhost",
+			},
+			"replicas": []interface{}{},
+		},
+		"servers": []interface{}{
+			map[string]interface{}{
+				"port": "8080",
+			},
+			nil,
+			[]interface{}{"a"},
+		},
+		"dotted.key": map[string]interface{}{},
+	}
+
+	expected := map[string]interface{}{
+		"db.primary.host": "localhost",
+		"db.replicas":     []interface{}{},
+		"servers[0].port": "8080",
+		"servers[1]":      nil,
+		"servers[2][0]":   "a",
+		`dotted\.key`:     map[string]interface{}{},
+	}
+
+	flat := NewVoorhees(input).Flatten()
+
+	assert.Equal(t, expected, flat)
+
+	result, err := Unflatten(flat)
+
+	assert.NoError(t, err)
+	assert.Equal(t, deepCopy(input), result)
+}
+
+func TestUnflatten(t *testing.T) {
+	flat := map[string]interface{}{
+		"servers[1].port": "8081",
+		"servers[0].port": "8080",
+		"db.primary.host": "localhost",
+	}
+
+	expected := map[string]interface{}{
+		"db": map[string]interface{}{
+			"primary": map[string]interface{}{
+				"host": "localhost",
+			},
+		},
+		"servers": []interface{}{
+			map[string]interface{}{
+				"port": "8080",
+			},
+			map[string]interface{}{
+				"port": "8081",
+			},
+		},
+	}
+
+	result, err := Unflatten(flat)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestUnflattenConflictingPaths(t *testing.T) {
+	flat := map[string]interface{}{
+		"a":   "leaf",
+		"a.b": "uhoh",
+	}
+
+	result, err := Unflatten(flat)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+}
+
+func TestFlattenEmptyKeys(t *testing.T) {
+	input := map[string]interface{}{
+		"":  map[string]interface{}{"x": 1.0, "": []interface{}{2.0}},
+		"x": "not the same as the x above",
+	}
+
+	flat := NewVoorhees(input).Flatten()
+	assert.Equal(t, map[string]interface{}{
+		`\"\".x`:       1.0,
+		`\"\".\"\"[0]`: 2.0,
+		"x":            "not the same as the x above",
+	}, flat)
+
+	result, err := Unflatten(flat)
+	assert.NoError(t, err)
+	assert.Equal(t, input, result)
+}
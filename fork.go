@@ -0,0 +1,54 @@
+package voorhees
+
+// IndependentResults makes every map returned by Get, Add, Change, Delete, Apply, Undo, Redo and Restore independent
+// of the live document, so a stored result is not affected by any subsequent operations.
+// Ordinarily each result is a deep copy. In CopyOnWrite mode the result is instead shared, and the Voorhees instance
+// copies any shared node before it next modifies it, so only the nodes along the path of each operation are copied.
+// As with any document in CopyOnWrite mode, results must then not be modified by the caller.
+func IndependentResults() Option {
+	return func(v *Voorhees) {
+		v.independent = true
+	}
+}
+
+// Fork creates a new Voorhees instance with the same document and options as v, which can then be manipulated
+// independently, i.e to branch a base fixture into many variants. Snapshots are carried over, but the history of
+// operations starts afresh in the fork, and watchers are not carried over.
+// In CopyOnWrite mode both instances share the document, and copy nodes only as they modify them.
+func (v *Voorhees) Fork() *Voorhees {
+	fork := &Voorhees{
+		JSON:        v.detach(v.JSON).(map[string]interface{}),
+		cow:         v.cow,
+		snapshots:   append([]map[string]interface{}{}, v.snapshots...), // snapshots are never modified in place
+		independent: v.independent,
+		schema:      v.schema,
+	}
+
+	if v.history != nil {
+		fork.history = &history{}
+	}
+
+	return fork
+}
+
+func (v *Voorhees) result(json map[string]interface{}, err error) (map[string]interface{}, error) {
+	if err != nil || !v.independent {
+		return json, err
+	}
+
+	return v.detach(json).(map[string]interface{}), nil
+}
+
+// detach returns a version of x that will not be modified by any subsequent operations on v.
+func (v *Voorhees) detach(x interface{}) interface{} {
+	if !v.cow {
+		return clone(x)
+	}
+
+	switch x.(type) {
+	case map[string]interface{}, []interface{}:
+		v.owned = nil // everything is now shared, so will be copied before being modified
+	}
+
+	return x
+}
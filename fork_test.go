@@ -0,0 +1,108 @@
+package voorhees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndependentResults(t *testing.T) {
+	for name, opts := range map[string][]Option{
+		"deep copy":     {IndependentResults()},
+		"copy on write": {IndependentResults(), CopyOnWrite()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			v := NewVoorhees(copyInput(testInput), opts...)
+
+			added, err := v.Add("layer1.added", "excellent")
+			assert.NoError(t, err)
+			array, err := v.Get("layer1.array")
+			assert.NoError(t, err)
+
+			v.Change("layer1.added", "changed")
+			v.Delete("layer1.array[0]")
+			changed, _ := v.Change("keepMe", "changed")
+
+			expected := copyInput(testInput)
+			expected["layer1"].(map[string]interface{})["added"] = "excellent"
+			assert.Equal(t, expected, added, "Expected the result of Add to be unaffected by later operations")
+			assert.Equal(t, []interface{}{"a", "b"}, array, "Expected the result of Get to be unaffected by later operations")
+
+			assert.Equal(t, "changed", changed["keepMe"])
+		})
+	}
+}
+
+func TestIndependentResultsCanBeModified(t *testing.T) {
+	v := NewVoorhees(testInput, IndependentResults())
+
+	changed, _ := v.Change("keepMe", "changed")
+	changed["keepMe"] = "modified by the caller"
+
+	assert.Equal(t, "changed", v.JSON["keepMe"], "Expected modifying a result to not affect the live document")
+}
+
+func TestResultsAliasByDefault(t *testing.T) {
+	v := NewVoorhees(testInput)
+
+	added, _ := v.Add("layer1.added", "excellent")
+	v.Change("layer1.added", "changed")
+
+	assert.Equal(t, "changed", added["layer1"].(map[string]interface{})["added"])
+}
+
+func TestFork(t *testing.T) {
+	for name, opts := range map[string][]Option{
+		"deep copy":     nil,
+		"copy on write": {CopyOnWrite()},
+		"history":       {RecordHistory()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := copyInput(testInput)
+			base := NewVoorhees(input, opts...)
+			base.Add("layer1.shared", "added before forking")
+
+			a := base.Fork()
+			b := base.Fork()
+
+			a.Change("layer1.array[0]", "changed by a")
+			b.Delete("layer1.array[0]")
+			b.Add("layer1.added", "added by b")
+			base.Change("keepMe", "changed by base")
+
+			assert.Equal(t, []interface{}{"changed by a", "b"}, a.JSON["layer1"].(map[string]interface{})["array"])
+			assert.Equal(t, []interface{}{"b"}, b.JSON["layer1"].(map[string]interface{})["array"])
+			assert.Equal(t, []interface{}{"a", "b"}, base.JSON["layer1"].(map[string]interface{})["array"])
+
+			assert.NotContains(t, a.JSON["layer1"], "added")
+			assert.NotContains(t, base.JSON["layer1"], "added")
+			assert.Equal(t, "please", a.JSON["keepMe"])
+			assert.Equal(t, "please", b.JSON["keepMe"])
+
+			for _, v := range []*Voorhees{base, a, b} {
+				assert.Equal(t, "added before forking", v.JSON["layer1"].(map[string]interface{})["shared"])
+			}
+			assert.Equal(t, testInput, input, "Expected the source document to never be modified")
+		})
+	}
+}
+
+func TestForkStartsAFreshHistory(t *testing.T) {
+	base := NewVoorhees(testInput, RecordHistory())
+	base.Change("keepMe", "changed")
+	snapshot := base.Snapshot()
+
+	fork := base.Fork()
+	_, err := fork.Undo()
+	assert.Equal(t, ErrNothingToUndo, err)
+
+	fork.Change("keepMe", "changed again")
+	result, err := fork.Restore(snapshot)
+	assert.NoError(t, err)
+	assert.Equal(t, "changed", result["keepMe"])
+
+	result, err = fork.Undo()
+	assert.NoError(t, err)
+	assert.Equal(t, "changed again", result["keepMe"])
+	assert.Equal(t, "changed", base.JSON["keepMe"])
+}
@@ -0,0 +1,138 @@
+package voorhees
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+// RandomOperations generates n random operations against src, using an RNG seeded with seed so that the same
+// seed always produces the same operations. Every operation is valid against the document produced by applying
+// those before it to src, so the result can be passed straight to Apply.
+//
+// The seed and count are deliberately simple types, so they can be provided by a native Go fuzz target:
+//
+//	f.Fuzz(func(t *testing.T, seed int64, n uint8) {
+//		ops := voorhees.RandomOperations(fixture, seed, int(n))
+//		...
+//	})
+func RandomOperations(src map[string]interface{}, seed int64, n int) []Operation {
+	if n < 0 {
+		n = 0
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	v := NewVoorhees(src)
+	ops := make([]Operation, 0, n)
+
+	for len(ops) < n {
+		op := randomOperation(rng, v)
+
+		if err := v.apply(op.copy()); err != nil {
+			continue // the chosen node couldn't be manipulated, so we simply try again
+		}
+
+		ops = append(ops, op)
+	}
+
+	return ops
+}
+
+// Shrink minimises ops to a smaller set of operations that still cause failing to return true once applied to src,
+// allowing a failing fuzz case to be reduced to the fewest edits that reproduce it. Shrinking is deterministic.
+// Operations that can no longer be applied once others have been removed are skipped.
+func Shrink(src map[string]interface{}, ops []Operation, failing func(map[string]interface{}) bool) []Operation {
+	if !failing(applyLeniently(src, ops)) {
+		return ops
+	}
+
+	for chunkSize := (len(ops) + 1) / 2; chunkSize >= 1; {
+		removed := false
+
+		for start := 0; start < len(ops); {
+			end := start + chunkSize
+			if end > len(ops) {
+				end = len(ops)
+			}
+
+			candidate := append(append([]Operation{}, ops[:start]...), ops[end:]...)
+
+			if failing(applyLeniently(src, candidate)) {
+				ops = candidate
+				removed = true
+				continue // the next chunk has shifted into start
+			}
+
+			start = end
+		}
+
+		if !removed {
+			chunkSize /= 2
+		}
+	}
+
+	return ops
+}
+
+func applyLeniently(src map[string]interface{}, ops []Operation) map[string]interface{} {
+	v := NewVoorhees(src)
+
+	for _, op := range ops {
+		v.apply(op.copy()) // ops are applied repeatedly, so must never be modified by those that follow them
+	}
+
+	return v.JSON
+}
+
+func randomOperation(rng *rand.Rand, v *Voorhees) Operation {
+	paths := v.Paths(PathOptions{})
+
+	switch op := rng.Intn(3); {
+	case op == 0 || len(paths) == 0:
+		return Operation{OpAdd, randomAddPath(rng, v, paths), randomValue(rng)}
+	case op == 1:
+		return Operation{OpChange, paths[rng.Intn(len(paths))], randomValue(rng)}
+	default:
+		return Operation{Op: OpDelete, Path: paths[rng.Intn(len(paths))]}
+	}
+}
+
+// randomAddPath picks a map or array from the document (including the root) and returns a path that adds a new
+// property to it, or appends to it in the case of arrays.
+func randomAddPath(rng *rand.Rand, v *Voorhees, paths []string) string {
+	parents := []string{""}
+
+	for _, path := range paths {
+		val, _ := v.Get(path)
+
+		switch val.(type) {
+		case map[string]interface{}, []interface{}:
+			parents = append(parents, path)
+		}
+	}
+
+	parent := parents[rng.Intn(len(parents))]
+	val, _ := v.Get(parent)
+
+	if a, isArray := val.([]interface{}); isArray && parent != "" {
+		return indexPath(parent, len(a))
+	}
+
+	return joinPath(parent, "fuzz"+strconv.Itoa(rng.Intn(100)))
+}
+
+func randomValue(rng *rand.Rand) interface{} {
+	switch rng.Intn(6) {
+	case 0:
+		return "fuzz" + strconv.Itoa(rng.Intn(100))
+	case 1:
+		return float64(rng.Intn(1000))
+	case 2:
+		return rng.Intn(2) == 0
+	case 3:
+		return nil
+	case 4:
+		return map[string]interface{}{}
+	default:
+		return []interface{}{}
+	}
+}
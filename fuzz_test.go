@@ -0,0 +1,107 @@
+package voorhees
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var fuzzInput = map[string]interface{}{
+	"keepMe": "please",
+	"layer1": map[string]interface{}{
+		"array": []interface{}{"a", "b"},
+	},
+}
+
+func TestRandomOperationsAreReproducible(t *testing.T) {
+	first := RandomOperations(fuzzInput, 42, 20)
+	second := RandomOperations(fuzzInput, 42, 20)
+
+	assert.Len(t, first, 20)
+	assert.Equal(t, first, second)
+	assert.NotEqual(t, first, RandomOperations(fuzzInput, 43, 20))
+}
+
+func TestRandomOperationsAreApplicable(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		ops := RandomOperations(fuzzInput, seed, 10)
+
+		_, err := NewVoorhees(fuzzInput).Apply(ops...)
+
+		assert.NoError(t, err, "Expected operations generated from seed %d to apply cleanly", seed)
+	}
+}
+
+func TestRandomOperationsAreNotModifiedByApplying(t *testing.T) {
+	assert.Empty(t, RandomOperations(fuzzInput, 1, -1))
+
+	ops := RandomOperations(map[string]interface{}{}, 13, 20)
+	Shrink(map[string]interface{}{}, ops, func(doc map[string]interface{}) bool { return true })
+
+	for i, op := range ops {
+		switch val := op.Value.(type) {
+		case map[string]interface{}:
+			assert.Empty(t, val, "Expected the map added by operation %d to be left untouched", i)
+		case []interface{}:
+			assert.Empty(t, val, "Expected the array added by operation %d to be left untouched", i)
+		}
+	}
+}
+
+func TestShrink(t *testing.T) {
+	ops := []Operation{
+		Operation{OpAdd, "a", "excellent"},
+		Operation{OpChange, "keepMe", "changed"},
+		Operation{OpAdd, "layer1.culprit", true},
+		Operation{OpDelete, "layer1.array[0]", nil},
+		Operation{OpAdd, "b", "excellent"},
+	}
+
+	failing := func(doc map[string]interface{}) bool {
+		culprit, _ := NewVoorhees(doc).Get("layer1.culprit")
+		return culprit == true
+	}
+
+	assert.Equal(t, []Operation{Operation{OpAdd, "layer1.culprit", true}}, Shrink(fuzzInput, ops, failing))
+}
+
+func TestShrinkSkipsOperationsThatNoLongerApply(t *testing.T) {
+	ops := []Operation{
+		Operation{OpAdd, "layer2.culprit", "excellent"},
+		Operation{OpChange, "layer2.culprit", "uhoh"},
+	}
+
+	failing := func(doc map[string]interface{}) bool {
+		culprit, _ := NewVoorhees(doc).Get("layer2.culprit")
+		return culprit == "uhoh"
+	}
+
+	assert.Equal(t, ops, Shrink(fuzzInput, ops, failing))
+}
+
+func FuzzRandomOperations(f *testing.F) {
+	f.Add(int64(0), uint8(5))
+	f.Add(int64(1), uint8(50))
+
+	survivesFlatten := func(doc map[string]interface{}) bool {
+		result, err := Unflatten(NewVoorhees(doc).Flatten())
+		return err == nil && reflect.DeepEqual(doc, result)
+	}
+
+	f.Fuzz(func(t *testing.T, seed int64, n uint8) {
+		ops := RandomOperations(fuzzInput, seed, int(n))
+
+		result, err := NewVoorhees(fuzzInput).Apply(ops...)
+		if err != nil {
+			t.Fatalf("Expected operations generated from seed %d to apply cleanly: %s", seed, err)
+		}
+
+		if !survivesFlatten(result) {
+			minimal := Shrink(fuzzInput, ops, func(doc map[string]interface{}) bool {
+				return !survivesFlatten(doc)
+			})
+			t.Fatalf("Expected result to survive a round trip through Flatten. Minimal operations: %+v", minimal)
+		}
+	})
+}
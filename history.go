@@ -0,0 +1,226 @@
+package voorhees
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrNothingToUndo and ErrNothingToRedo are returned by Undo and Redo when there is no operation to step over.
+var (
+	ErrNothingToUndo = errors.New("[Voorhees]: Nothing to undo")
+	ErrNothingToRedo = errors.New("[Voorhees]: Nothing to redo")
+)
+
+// RecordHistory records the inverse of every successful Add, Change, Delete and Restore, so they can be stepped
+// back over with Undo and Redo.
+func RecordHistory() Option {
+	return func(v *Voorhees) {
+		v.history = &history{}
+	}
+}
+
+// history holds the revisions that can be undone, and those that have been undone and can be redone.
+type history struct {
+	undo []revision
+	redo []revision
+}
+
+// revision is a single undoable step, described by the operations that make it and those that reverse it.
+type revision struct {
+	redo []Operation
+	undo []Operation
+}
+
+func (h *history) record(r revision) {
+	h.undo = append(h.undo, r)
+	h.redo = nil // a new revision branches away from anything that was undone
+}
+
+// Undo reverts the most recent operation, returning the document as it was before that operation was applied.
+// RecordHistory must have been passed to NewVoorhees for there to be anything to undo.
+func (v *Voorhees) Undo() (map[string]interface{}, error) {
+	defer v.notify()
+
+	if v.history == nil || len(v.history.undo) == 0 {
+		return nil, ErrNothingToUndo
+	}
+
+	last := len(v.history.undo) - 1
+	r := v.history.undo[last]
+
+	if err := v.replay(r.undo); err != nil {
+		return nil, err
+	}
+
+	v.history.undo = v.history.undo[:last]
+	v.history.redo = append(v.history.redo, r)
+
+	return v.result(v.JSON, nil)
+}
+
+// Redo reapplies the most recently undone operation. Any new operation after an Undo discards what can be redone.
+func (v *Voorhees) Redo() (map[string]interface{}, error) {
+	defer v.notify()
+
+	if v.history == nil || len(v.history.redo) == 0 {
+		return nil, ErrNothingToRedo
+	}
+
+	last := len(v.history.redo) - 1
+	r := v.history.redo[last]
+
+	if err := v.replay(r.redo); err != nil {
+		return nil, err
+	}
+
+	v.history.redo = v.history.redo[:last]
+	v.history.undo = append(v.history.undo, r)
+
+	return v.result(v.JSON, nil)
+}
+
+// Snapshot captures the current state of the document, returning an id that can later be passed to Restore.
+// Snapshots are independent copies, so are unaffected by any subsequent operations.
+func (v *Voorhees) Snapshot() int {
+	v.snapshots = append(v.snapshots, clone(v.JSON).(map[string]interface{}))
+	return len(v.snapshots) - 1
+}
+
+// Restore returns the document to the state captured by the Snapshot with the provided id.
+// When recording history, Restore is itself a single step that can be undone.
+func (v *Voorhees) Restore(id int) (map[string]interface{}, error) {
+	defer v.notify()
+
+	if id < 0 || id >= len(v.snapshots) {
+		return nil, fmt.Errorf("[Voorhees]: Unable to restore snapshot %d because it doesn't exist", id)
+	}
+
+	r := revision{
+		redo: replaceDocument(v.JSON, clone(v.snapshots[id]).(map[string]interface{})),
+		undo: replaceDocument(v.snapshots[id], v.JSON),
+	}
+
+	if err := v.replay(r.redo); err != nil {
+		return nil, err
+	}
+
+	if v.history != nil {
+		v.history.record(r)
+	}
+
+	return v.result(v.JSON, nil)
+}
+
+// replay performs ops without recording them in the history. ops may be replayed again, so each value is copied
+// rather than becoming part of the document.
+func (v *Voorhees) replay(ops []Operation) error {
+	for _, op := range ops {
+		if _, err := v.perform(op.copy()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// inverse returns the operations that undo op, based on the current state of the document. Nothing is returned
+// for operations that will fail, or that will not change the document.
+func (v *Voorhees) inverse(op Operation) []Operation {
+	tokens, err := tokenizePath(op.Path, false)
+	if err != nil || len(tokens) == 0 {
+		return nil
+	}
+
+	var node interface{} = v.JSON
+	for i, t := range tokens {
+		node = v.prepare(node, false)
+		isFinal := i == len(tokens)-1
+
+		if node == nil && op.Op == OpAdd && i > 0 {
+			// Add creates the missing node within the null array element, so put the null back
+			return []Operation{{Op: OpChange, Path: formatPath(tokens[:i]), Value: nil}}
+		}
+
+		if t.isIndex {
+			a, isArray := node.([]interface{})
+			if !isArray {
+				return nil
+			}
+
+			if t.index >= len(a) || (isFinal && op.Op == OpDelete) {
+				// Add grows the array, and Delete shrinks it, so restore the array as it is now. The nodes inside it
+				// are copied too, as they remain part of the document and may be modified in place.
+				return []Operation{{Op: OpChange, Path: formatPath(tokens[:i]), Value: clone(a)}}
+			}
+
+			node = a[t.index]
+			continue
+		}
+
+		m, isMap := node.(map[string]interface{})
+		if !isMap {
+			return nil
+		}
+
+		val, exists := m[t.key]
+		switch {
+		case !exists && op.Op == OpAdd:
+			return []Operation{{Op: OpDelete, Path: formatPath(tokens[:i+1])}}
+		case !exists:
+			return nil
+		case isFinal && op.Op == OpDelete:
+			return []Operation{{Op: OpAdd, Path: formatPath(tokens), Value: val}}
+		}
+
+		node = val
+	}
+
+	return []Operation{{Op: OpChange, Path: formatPath(tokens), Value: node}}
+}
+
+// replaceDocument returns the operations that turn the document from into the document to, key by key.
+func replaceDocument(from, to map[string]interface{}) []Operation {
+	var ops []Operation
+
+	for _, k := range sortedKeys(from) {
+		ops = append(ops, Operation{Op: OpDelete, Path: escapeProperty(k)})
+	}
+
+	for _, k := range sortedKeys(to) {
+		ops = append(ops, Operation{Op: OpAdd, Path: escapeProperty(k), Value: to[k]})
+	}
+
+	return ops
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// clone deep copies the maps and arrays within x, sharing only the leaf values.
+func clone(x interface{}) interface{} {
+	if m, isMap := asMap(x); isMap {
+		copied := make(map[string]interface{}, len(m))
+		for k, val := range m {
+			copied[k] = clone(val)
+		}
+		return copied
+	}
+
+	if a, isArray := asArray(x); isArray {
+		copied := make([]interface{}, len(a))
+		for i, val := range a {
+			copied[i] = clone(val)
+		}
+		return copied
+	}
+
+	return x
+}
@@ -0,0 +1,169 @@
+package voorhees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUndoRedo(t *testing.T) {
+	ops := []Operation{
+		{OpChange, "layer1.changeMe", "changed"},
+		{OpAdd, "keepMe", map[string]interface{}{"replaced": true}},
+		{OpAdd, "layer2.layer3.added", "excellent"},
+		{OpAdd, "layer1.array[4]", "grown"},
+		{OpAdd, "layer1.array[2].created", "inside null"},
+		{Op: OpDelete, Path: "layer1.array[0]"},
+		{OpChange, "layer1.matrix[0][1]", "changed"},
+		{OpAdd, "layer1.matrix[1][0]", "added"},
+		{Op: OpDelete, Path: "layer1.matrix"},
+		{Op: OpDelete, Path: "layer1.missing"},
+		{OpAdd, "escaped\\.key", 1.0},
+	}
+
+	for name, opts := range map[string][]Option{
+		"deep copy":     {RecordHistory()},
+		"copy on write": {RecordHistory(), CopyOnWrite()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			input := copyInput(historyInput)
+			v := NewVoorhees(input, opts...)
+
+			states := []map[string]interface{}{clone(v.JSON).(map[string]interface{})}
+			for _, op := range ops {
+				_, err := v.Apply(op)
+				assert.NoError(t, err, op.Path)
+				states = append(states, clone(v.JSON).(map[string]interface{}))
+			}
+
+			for i := len(ops) - 1; i >= 0; i-- {
+				result, err := v.Undo()
+				assert.NoError(t, err)
+				assert.Equal(t, states[i], result, "Expected undo of %s %s to restore the previous state", ops[i].Op, ops[i].Path)
+			}
+
+			_, err := v.Undo()
+			assert.Equal(t, ErrNothingToUndo, err)
+
+			for i := range ops {
+				result, err := v.Redo()
+				assert.NoError(t, err)
+				assert.Equal(t, states[i+1], result, "Expected redo of %s %s to reapply it", ops[i].Op, ops[i].Path)
+			}
+
+			_, err = v.Redo()
+			assert.Equal(t, ErrNothingToRedo, err)
+			assert.Equal(t, historyInput, input, "Expected the source document to never be modified")
+		})
+	}
+}
+
+func TestUndoRedoRandomOperations(t *testing.T) {
+	input := map[string]interface{}{"a": []interface{}{[]interface{}{1.0, 2.0}}}
+
+	// each batch of operations is applied as a single step, with batches of one applied individually
+	for _, batchSize := range []int{1, 3} {
+		for seed := int64(0); seed < 100; seed++ {
+			for _, opts := range [][]Option{{RecordHistory()}, {RecordHistory(), CopyOnWrite()}} {
+				ops := RandomOperations(input, seed, 21)
+				v := NewVoorhees(input, opts...)
+
+				var steps int
+				states := []map[string]interface{}{clone(v.JSON).(map[string]interface{})}
+				for i := 0; i < len(ops); i += batchSize {
+					if batchSize == 1 {
+						v.Apply(ops[i])
+					} else {
+						v.applyAtomically(ops[i : i+batchSize])
+					}
+					states = append(states, clone(v.JSON).(map[string]interface{}))
+					steps++
+				}
+
+				for i := steps - 1; i >= 0; i-- {
+					result, _ := v.Undo()
+					if !assert.Equal(t, states[i], result, "Expected undo of step %d from seed %d to restore the "+
+						"previous state", i, seed) {
+						return
+					}
+				}
+
+				for i := 0; i < steps; i++ {
+					result, _ := v.Redo()
+					if !assert.Equal(t, states[i+1], result, "Expected redo of step %d from seed %d to reapply it",
+						i, seed) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestUndoDiscardsRedoOnNewOperation(t *testing.T) {
+	v := NewVoorhees(historyInput, RecordHistory())
+
+	v.Change("keepMe", "first")
+	v.Undo()
+	v.Change("keepMe", "second")
+
+	_, err := v.Redo()
+	assert.Equal(t, ErrNothingToRedo, err)
+	assert.Equal(t, "second", v.JSON["keepMe"])
+}
+
+func TestFailedOperationsAreNotRecorded(t *testing.T) {
+	v := NewVoorhees(historyInput, RecordHistory())
+
+	v.Change("keepMe", "changed")
+	_, err := v.Change("layer1.doesNotExist", "changed")
+	assert.Error(t, err)
+
+	result, err := v.Undo()
+	assert.NoError(t, err)
+	assert.Equal(t, historyInput, result)
+
+	_, err = v.Undo()
+	assert.Equal(t, ErrNothingToUndo, err)
+}
+
+func TestUndoWithoutHistory(t *testing.T) {
+	v := NewVoorhees(historyInput)
+	v.Change("keepMe", "changed")
+
+	_, err := v.Undo()
+	assert.Equal(t, ErrNothingToUndo, err)
+	assert.Nil(t, v.history, "Expected no history to be recorded unless requested")
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	v := NewVoorhees(historyInput, RecordHistory())
+
+	original := v.Snapshot()
+	v.Change("layer1.array[0]", "changed")
+	v.Add("added", "excellent")
+	changed := v.Snapshot()
+	v.Delete("layer1")
+
+	result, err := v.Restore(original)
+	assert.NoError(t, err)
+	assert.Equal(t, historyInput, result)
+
+	result, err = v.Restore(changed)
+	assert.NoError(t, err)
+	assert.Equal(t, "excellent", result["added"])
+	assert.Equal(t, "changed", result["layer1"].(map[string]interface{})["array"].([]interface{})[0])
+
+	v.Change("layer1.array[0]", "changed again")
+	result, _ = v.Restore(changed)
+	assert.Equal(t, "changed", result["layer1"].(map[string]interface{})["array"].([]interface{})[0],
+		"Expected snapshots to be unaffected by operations made after restoring them")
+
+	result, err = v.Undo()
+	assert.NoError(t, err)
+	assert.Equal(t, "changed again", result["layer1"].(map[string]interface{})["array"].([]interface{})[0],
+		"Expected Restore to be undoable")
+
+	_, err = v.Restore(2)
+	assert.EqualError(t, err, "[Voorhees]: Unable to restore snapshot 2 because it doesn't exist")
+}
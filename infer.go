@@ -0,0 +1,89 @@
+package voorhees
+
+import (
+	"sort"
+)
+
+// InferSchema produces a Schema describing the example documents provided. The schema records the types found at
+// each node, including null for nodes that are null in any of the samples, the properties of each object, and the
+// items of each array. Properties present in every sample of an object are marked as required.
+// Numbers are described as integer unless any sample has a fractional part. No other constraints are inferred.
+func InferSchema(docs ...map[string]interface{}) *Schema {
+	samples := make([]interface{}, len(docs))
+	for i, doc := range docs {
+		samples[i] = doc
+	}
+
+	return inferSchema(samples)
+}
+
+// inferSchema produces a Schema that describes every one of samples, which are all found at the same node.
+func inferSchema(samples []interface{}) *Schema {
+	s := &Schema{}
+	kinds := map[string]bool{}
+
+	var objects []map[string]interface{}
+	var items []interface{}
+
+	for _, sample := range samples {
+		kind := string(KindOf(sample))
+		if n, isNumber := asNumber(sample); isNumber && n.IsInt() {
+			kind = "integer"
+		}
+		kinds[kind] = true
+
+		if m, isMap := asMap(sample); isMap {
+			objects = append(objects, m)
+		}
+
+		if a, isArray := asArray(sample); isArray {
+			items = append(items, a...)
+		}
+	}
+
+	if kinds["integer"] && kinds["number"] {
+		delete(kinds, "integer") // every integer is also a number
+	}
+
+	for _, kind := range []string{"object", "array", "string", "number", "integer", "boolean", "null"} {
+		if kinds[kind] {
+			s.Type = append(s.Type, kind)
+		}
+	}
+
+	if len(objects) > 0 {
+		s.inferProperties(objects)
+	}
+
+	if len(items) > 0 {
+		s.Items = inferSchema(items)
+	}
+
+	return s
+}
+
+// inferProperties describes the properties found across objects, requiring those present in all of them.
+func (s *Schema) inferProperties(objects []map[string]interface{}) {
+	values := map[string][]interface{}{}
+	var keys []string
+
+	for _, m := range objects {
+		for k, val := range m {
+			if _, seen := values[k]; !seen {
+				keys = append(keys, k)
+			}
+			values[k] = append(values[k], val)
+		}
+	}
+
+	sort.Strings(keys)
+
+	s.Properties = make(map[string]*Schema, len(keys))
+	for _, k := range keys {
+		s.Properties[k] = inferSchema(values[k])
+
+		if len(values[k]) == len(objects) {
+			s.Required = append(s.Required, k)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package voorhees
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInferSchema(t *testing.T) {
+	a := map[string]interface{}{
+		"id":       1.0,
+		"name":     "Jason",
+		"score":    2.0,
+		"nickname": nil,
+		"active":   true,
+		"tags":     []interface{}{"camp", "lake"},
+		"address":  map[string]interface{}{"city": "Crystal Lake", "zip": 12345.0},
+		"matrix":   []interface{}{[]interface{}{1.0, 2.0}},
+	}
+	b := map[string]interface{}{
+		"id":       2,
+		"name":     "Pamela",
+		"score":    2.5,
+		"nickname": "Pam",
+		"tags":     []interface{}{},
+		"address":  map[string]interface{}{"city": "Crystal Lake"},
+		"matrix":   []interface{}{[]interface{}{}, nil},
+		"mixed":    []interface{}{1.0, "a", map[string]interface{}{"x": true}},
+	}
+
+	schema := InferSchema(a, b)
+
+	expected := `{
+		"type": "object",
+		"required": ["address", "id", "matrix", "name", "nickname", "score", "tags"],
+		"properties": {
+			"active": {"type": "boolean"},
+			"address": {
+				"type": "object",
+				"required": ["city"],
+				"properties": {
+					"city": {"type": "string"},
+					"zip": {"type": "integer"}
+				}
+			},
+			"id": {"type": "integer"},
+			"matrix": {"type": "array", "items": {"type": ["array", "null"], "items": {"type": "integer"}}},
+			"mixed": {
+				"type": "array",
+				"items": {
+					"type": ["object", "string", "integer"],
+					"required": ["x"],
+					"properties": {"x": {"type": "boolean"}}
+				}
+			},
+			"name": {"type": "string"},
+			"nickname": {"type": ["string", "null"]},
+			"score": {"type": "number"},
+			"tags": {"type": "array", "items": {"type": "string"}}
+		}
+	}`
+
+	expectedSchema, err := ParseSchema([]byte(expected))
+	assert.NoError(t, err)
+	assert.Equal(t, expectedSchema, schema)
+
+	marshalled, err := json.Marshal(schema)
+	assert.NoError(t, err)
+	assert.Contains(t, string(marshalled), `"nickname":{"type":["string","null"]}`)
+
+	assert.NoError(t, schema.Validate(a))
+	assert.NoError(t, schema.Validate(b))
+	assert.Error(t, schema.Validate(map[string]interface{}{"id": 1.0}))
+}
+
+func TestInferSchemaWithoutSamples(t *testing.T) {
+	schema := InferSchema()
+
+	assert.NoError(t, schema.Validate(map[string]interface{}{"anything": true}))
+}
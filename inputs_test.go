@@ -0,0 +1,115 @@
+package voorhees
+
+// The documents shared by the tests. testInput suits any test, while the others are shaped for a single feature.
+// Tests that may modify a document, or that share it with a Voorhees in CopyOnWrite mode, should take a copy with
+// copyInput, leaving the original to compare against.
+var (
+	cowInput = map[string]interface{}{
+		"keepMe": "please",
+		"shared": map[string]interface{}{
+			"untouched": []interface{}{"a"},
+		},
+		"layer1": map[string]interface{}{
+			"changeMe": "please",
+			"array": []map[string]interface{}{
+				map[string]interface{}{"deleteMe": "please"},
+			},
+			"matrix": []interface{}{
+				[]interface{}{"a", "b"},
+			},
+		},
+	}
+
+	testInput = map[string]interface{}{
+		"keepMe": "please",
+		"layer1": map[string]interface{}{
+			"changeMe": "please",
+			"array":    []interface{}{"a", "b"},
+		},
+	}
+
+	historyInput = map[string]interface{}{
+		"keepMe": "please",
+		"layer1": map[string]interface{}{
+			"changeMe": "please",
+			"array":    []interface{}{"a", "b", nil},
+			"matrix": []interface{}{
+				[]interface{}{"a", "b"},
+			},
+		},
+	}
+
+	schemaInput = map[string]interface{}{
+		"name": "Jason",
+		"age":  40.0,
+		"tags": []interface{}{"camp", "lake"},
+		"address": map[string]interface{}{
+			"city":   "Crystal Lake",
+			"street": "Camp Road",
+		},
+	}
+
+	syncInput = map[string]interface{}{
+		"counters": map[string]interface{}{
+			"a": 0.0,
+			"b": 0.0,
+		},
+		"history": []interface{}{},
+		"static":  map[string]interface{}{"untouched": []interface{}{"a", "b"}},
+	}
+
+	updateInput = map[string]interface{}{
+		"count":   1.0,
+		"enabled": true,
+		"name":    "camp crystal lake",
+		"tags":    []interface{}{"b"},
+		"items": []interface{}{
+			map[string]interface{}{"price": 10.0, "name": "axe"},
+			map[string]interface{}{"price": 2.5, "name": "mask"},
+			map[string]interface{}{"name": "priceless"},
+		},
+		"matrix": []interface{}{
+			[]interface{}{1.0, 2.0},
+			[]interface{}{3.0},
+		},
+	}
+
+	watchInput = map[string]interface{}{
+		"db": map[string]interface{}{
+			"primary": map[string]interface{}{"host": "10.0.0.1", "port": 5432.0},
+			"replica": map[string]interface{}{"host": "10.0.0.2", "port": 5432.0},
+		},
+		"cache": map[string]interface{}{"ttl": 60.0},
+	}
+)
+
+// copyInput deep copies doc, keeping the Go types within it (unlike deepCopy, which converts them to their JSON
+// equivalents), so any modification of a shared source document can be detected.
+func copyInput(doc map[string]interface{}) map[string]interface{} {
+	return copyNode(doc).(map[string]interface{})
+}
+
+func copyNode(x interface{}) interface{} {
+	switch node := x.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(node))
+		for k, val := range node {
+			copied[k] = copyNode(val)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(node))
+		for i, val := range node {
+			copied[i] = copyNode(val)
+		}
+		return copied
+	case []map[string]interface{}:
+		copied := make([]map[string]interface{}, len(node))
+		for i, val := range node {
+			copied[i] = copyNode(val).(map[string]interface{})
+		}
+		return copied
+	}
+
+	return x
+}
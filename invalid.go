@@ -0,0 +1,251 @@
+package voorhees
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	// NotInEnum mutations replace the node with a value that is not one of those allowed by its schema's enum.
+	NotInEnum MutationKind = "not-in-enum"
+	// OutOfRange mutations replace the node with a value just outside of the bounds allowed by its schema, i.e its
+	// minimum, maximum, length or number of items.
+	OutOfRange MutationKind = "out-of-range"
+)
+
+// InvalidMutations generates a variant of src for each constraint of s that can be violated by a node present in
+// src: every required property is deleted, every typed property is type-swapped, every enum property is given a
+// value outside of the enum, and every bound is given a value just beyond it. Each variant is an independent deep
+// copy, and is verified to violate the constraint described by the Violation of the Mutation.
+func InvalidMutations(src map[string]interface{}, s *Schema) []Mutation {
+	source := NewVoorhees(src)
+
+	var mutations []Mutation
+	for _, c := range s.candidates(source.JSON, nil) {
+		v := NewVoorhees(source.JSON)
+		if c.kind == Deleted {
+			v.Delete(formatPath(c.path))
+		} else {
+			v.Change(formatPath(c.path), c.value)
+		}
+
+		violation := findViolation(s, v.JSON, c)
+		if violation == nil {
+			continue // the candidate did not violate the constraint, i.e a type swap that was also an allowed type
+		}
+
+		mutations = append(mutations, Mutation{
+			Name:      fmt.Sprintf("%s %s violates %s", c.kind, violation.Path, c.keyword),
+			Path:      violation.Path,
+			Kind:      c.kind,
+			JSON:      v.JSON,
+			Violation: violation,
+		})
+	}
+
+	return mutations
+}
+
+// invalidCandidate is a mutation expected to violate the keyword of a schema at path.
+type invalidCandidate struct {
+	path    []pathToken
+	keyword string
+	kind    MutationKind
+	value   interface{}
+}
+
+// candidates returns the mutations expected to violate a constraint of the schema, for node and its descendants.
+func (s *Schema) candidates(node interface{}, path []pathToken) []invalidCandidate {
+	if s == nil || s.boolean != nil {
+		return nil
+	}
+
+	var candidates []invalidCandidate
+	add := func(childPath []pathToken, keyword string, kind MutationKind, value interface{}) {
+		candidates = append(candidates, invalidCandidate{childPath, keyword, kind, value})
+	}
+
+	if len(path) > 0 { // the root of the document must remain a map
+		if len(s.Type) > 0 {
+			if swapped, ok := s.disallowedType(node); ok {
+				add(path, "type", TypeSwapped, swapped)
+			}
+		}
+
+		if len(s.Enum) > 0 {
+			add(path, "enum", NotInEnum, s.notInEnum(node))
+		}
+
+		for _, bound := range s.outOfRange(node) {
+			add(path, bound.keyword, OutOfRange, bound.value)
+		}
+	}
+
+	if m, isMap := asMap(node); isMap {
+		for _, k := range s.Required {
+			if _, exists := m[k]; exists {
+				add(append(path[:len(path):len(path)], pathToken{key: k}), "required", Deleted, nil)
+			}
+		}
+
+		for _, k := range sortedKeys(m) {
+			child, isProperty := s.Properties[k]
+			if !isProperty {
+				child = s.AdditionalProperties
+			}
+			childPath := append(path[:len(path):len(path)], pathToken{key: k})
+			candidates = append(candidates, child.candidates(m[k], childPath)...)
+		}
+	}
+
+	if a, isArray := asArray(node); isArray {
+		for i := range a {
+			childPath := append(path[:len(path):len(path)], pathToken{index: i, isIndex: true})
+			candidates = append(candidates, s.Items.candidates(a[i], childPath)...)
+		}
+	}
+
+	return candidates
+}
+
+// disallowedType returns a value of a type not allowed by the schema, derived from node where possible.
+func (s *Schema) disallowedType(node interface{}) (interface{}, bool) {
+	alternatives := []interface{}{"string", 0.5, 1.0, true, nil, map[string]interface{}{}, []interface{}{}}
+	if swapped, ok := swapType(node); ok {
+		alternatives = append([]interface{}{swapped}, alternatives...)
+	}
+
+	for _, alternative := range alternatives {
+		if !s.Type.allows(alternative) {
+			return alternative, true
+		}
+	}
+
+	return nil, false
+}
+
+// notInEnum returns a value that is not a member of the schema's enum, of the same type as node where possible.
+func (s *Schema) notInEnum(node interface{}) interface{} {
+	var candidate interface{}
+
+	switch n := node.(type) {
+	case bool:
+		candidate = !n
+	case float64:
+		candidate = n + 1
+		for _, allowed := range s.Enum {
+			if f, isFloat := allowed.(float64); isFloat && f >= candidate.(float64) {
+				candidate = f + 1
+			}
+		}
+	case string:
+		candidate = n + "_invalid"
+	default:
+		candidate = "invalid"
+	}
+
+	for s.allowsEnum(candidate) {
+		candidate = fmt.Sprintf("%v_invalid", candidate)
+	}
+
+	return candidate
+}
+
+type outOfRangeValue struct {
+	keyword string
+	value   interface{}
+}
+
+// outOfRange returns a value just beyond each bound of the schema that applies to node.
+func (s *Schema) outOfRange(node interface{}) []outOfRangeValue {
+	var values []outOfRangeValue
+
+	if _, isNumber := asNumber(node); isNumber {
+		integersOnly := s.Type.allows(1.0) && !s.Type.allows(0.5)
+		step := func(bound float64, direction float64) float64 {
+			if integersOnly {
+				return math.Floor(bound*direction)*direction + direction // the next integer beyond the bound
+			}
+			return math.Nextafter(bound, direction*math.Inf(1))
+		}
+
+		if s.Minimum != nil {
+			values = append(values, outOfRangeValue{"minimum", step(*s.Minimum, -1)})
+		}
+		if s.Maximum != nil {
+			values = append(values, outOfRangeValue{"maximum", step(*s.Maximum, 1)})
+		}
+		if s.ExclusiveMinimum != nil {
+			values = append(values, outOfRangeValue{"exclusiveMinimum", *s.ExclusiveMinimum})
+		}
+		if s.ExclusiveMaximum != nil {
+			values = append(values, outOfRangeValue{"exclusiveMaximum", *s.ExclusiveMaximum})
+		}
+	}
+
+	if str, isString := node.(string); isString {
+		if s.MinLength != nil && *s.MinLength > 0 {
+			values = append(values, outOfRangeValue{"minLength", resizeString(str, *s.MinLength-1)})
+		}
+		if s.MaxLength != nil {
+			values = append(values, outOfRangeValue{"maxLength", resizeString(str, *s.MaxLength+1)})
+		}
+	}
+
+	if a, isArray := asArray(node); isArray {
+		if s.MinItems != nil && *s.MinItems > 0 {
+			values = append(values, outOfRangeValue{"minItems", resizeArray(a, *s.MinItems-1)})
+		}
+		if s.MaxItems != nil {
+			values = append(values, outOfRangeValue{"maxItems", resizeArray(a, *s.MaxItems+1)})
+		}
+	}
+
+	return values
+}
+
+// resizeString truncates str, or pads it with x, to length characters.
+func resizeString(str string, length int) string {
+	runes := []rune(str)
+	if len(runes) >= length {
+		return string(runes[:length])
+	}
+
+	return str + strings.Repeat("x", length-len(runes))
+}
+
+// resizeArray truncates a, or pads it with copies of its last element, to length items.
+func resizeArray(a []interface{}, length int) []interface{} {
+	if len(a) >= length {
+		return append([]interface{}{}, a[:length]...)
+	}
+
+	resized := append([]interface{}{}, a...)
+	for len(resized) < length {
+		var padding interface{}
+		if len(a) > 0 {
+			padding = clone(a[len(a)-1])
+		}
+		resized = append(resized, padding)
+	}
+
+	return resized
+}
+
+// findViolation returns the error produced by validating doc that corresponds to the candidate c, if any.
+func findViolation(s *Schema, doc map[string]interface{}, c invalidCandidate) *SchemaError {
+	err := s.Validate(doc)
+	if err == nil {
+		return nil
+	}
+
+	path := formatPath(c.path)
+	for _, violation := range err.(SchemaErrors) {
+		if violation.Path == path && violation.Keyword == c.keyword {
+			return violation
+		}
+	}
+
+	return nil
+}
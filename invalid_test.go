@@ -0,0 +1,98 @@
+package voorhees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidMutations(t *testing.T) {
+	s, err := ParseSchema([]byte(`{
+		"type": "object",
+		"required": ["name", "age"],
+		"properties": {
+			"name": {"type": "string", "minLength": 2, "maxLength": 4},
+			"age": {"type": "integer", "minimum": 0, "maximum": 150},
+			"score": {"type": "number", "exclusiveMinimum": 0, "maximum": 1},
+			"role": {"type": "string", "enum": ["admin", "user"]},
+			"level": {"enum": [1, 2, 3]},
+			"tags": {"type": "array", "minItems": 1, "maxItems": 2, "items": {"type": "string"}},
+			"missing": {"type": "string"}
+		}
+	}`))
+	assert.NoError(t, err)
+
+	src := map[string]interface{}{
+		"name":  "Jay",
+		"age":   40.0,
+		"score": 0.5,
+		"role":  "admin",
+		"level": 2.0,
+		"tags":  []interface{}{"camp"},
+	}
+
+	type expectation struct {
+		path    string
+		kind    MutationKind
+		message string
+		value   interface{}
+	}
+
+	expected := map[string]expectation{
+		"deleted name violates required":               {"name", Deleted, "is required", nil},
+		"deleted age violates required":                {"age", Deleted, "is required", nil},
+		"type-swapped age violates type":               {"age", TypeSwapped, "expected integer, got string", "40"},
+		"out-of-range age violates minimum":            {"age", OutOfRange, "must be at least 0", -1.0},
+		"out-of-range age violates maximum":            {"age", OutOfRange, "must be at most 150", 151.0},
+		"not-in-enum level violates enum":              {"level", NotInEnum, "4 is not one of [1,2,3]", 4.0},
+		"type-swapped name violates type":              {"name", TypeSwapped, "expected string, got number", 3.0},
+		"out-of-range name violates minLength":         {"name", OutOfRange, "must be at least 2 characters long", "J"},
+		"out-of-range name violates maxLength":         {"name", OutOfRange, "must be at most 4 characters long", "Jayxx"},
+		"type-swapped role violates type":              {"role", TypeSwapped, "expected string, got number", 5.0},
+		"not-in-enum role violates enum":               {"role", NotInEnum, `"admin_invalid" is not one of ["admin","user"]`, "admin_invalid"},
+		"type-swapped score violates type":             {"score", TypeSwapped, "expected number, got string", "0.5"},
+		"out-of-range score violates exclusiveMinimum": {"score", OutOfRange, "must be greater than 0", 0.0},
+		"out-of-range score violates maximum":          {"score", OutOfRange, "must be at most 1", 1.0000000000000002},
+		"type-swapped tags violates type":              {"tags", TypeSwapped, "expected array, got object", map[string]interface{}{}},
+		"out-of-range tags violates minItems":          {"tags", OutOfRange, "must have at least 1 items", []interface{}{}},
+		"out-of-range tags violates maxItems":          {"tags", OutOfRange, "must have at most 2 items", []interface{}{"camp", "camp", "camp"}},
+		"type-swapped tags[0] violates type":           {"tags[0]", TypeSwapped, "expected string, got number", 4.0},
+	}
+
+	mutations := InvalidMutations(src, s)
+	assert.Len(t, mutations, len(expected))
+
+	for _, m := range mutations {
+		e, isExpected := expected[m.Name]
+		if !assert.True(t, isExpected, "Unexpected mutation %s", m.Name) {
+			continue
+		}
+
+		assert.Equal(t, e.path, m.Path, m.Name)
+		assert.Equal(t, e.kind, m.Kind, m.Name)
+		assert.Equal(t, e.message, m.Violation.Message, m.Name)
+
+		value, err := NewVoorhees(m.JSON).Get(m.Path)
+		if e.kind == Deleted {
+			assert.Error(t, err, m.Name)
+		} else {
+			assert.Equal(t, e.value, value, m.Name)
+		}
+
+		assert.Error(t, s.Validate(m.JSON), "Expected %s to be invalid", m.Name)
+	}
+
+	assert.NoError(t, s.Validate(src), "Expected the source document to be valid and unmodified")
+}
+
+func TestInvalidMutationsAreIndependent(t *testing.T) {
+	s, _ := ParseSchema([]byte(`{"required": ["a", "b"]}`))
+	src := map[string]interface{}{"a": 1.0, "b": 2.0}
+
+	mutations := InvalidMutations(src, s)
+	assert.Len(t, mutations, 2)
+
+	mutations[0].JSON["modified"] = true
+	assert.NotContains(t, mutations[1].JSON, "modified")
+	assert.NotContains(t, src, "modified")
+}
@@ -0,0 +1,93 @@
+package voorhees
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// JournalEntry is a single operation recorded by a Journal, along with the time it was applied.
+type JournalEntry struct {
+	Operation
+	Time time.Time `json:"time"` // recorded in UTC
+}
+
+// Journal records every operation applied to a document, so it can be exported as JSON and replayed onto another
+// document to reproduce the same result. A Journal records the operations it is notified of via Watch,
+// i.e v.Watch("", journal.Record). Undo, Redo and Restore are recorded as the operations they perform, so are
+// reproduced by Replay too.
+type Journal struct {
+	mu      sync.Mutex
+	entries []JournalEntry
+	now     func() time.Time
+}
+
+// NewJournal creates a new, empty Journal.
+func NewJournal() *Journal {
+	return &Journal{now: time.Now}
+}
+
+// Record adds the operation described by e to the journal. The value recorded is a copy, so is unaffected by any
+// subsequent operations.
+func (j *Journal) Record(e Event) {
+	op := Operation{Op: e.Op, Path: e.Path}
+	if e.Op != OpDelete {
+		op.Value = clone(e.New)
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries = append(j.entries, JournalEntry{Operation: op, Time: j.now().UTC()})
+}
+
+// Entries returns a copy of the entries recorded so far, in the order they were applied.
+func (j *Journal) Entries() []JournalEntry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries := make([]JournalEntry, len(j.entries))
+	for i, entry := range j.entries {
+		entries[i] = JournalEntry{Operation: entry.Operation.copy(), Time: entry.Time}
+	}
+
+	return entries
+}
+
+// Replay applies each of the recorded operations to v in turn, stopping at the first that fails. The operations
+// applied are copies, so the journal itself is never modified by replaying it.
+func (j *Journal) Replay(v *Voorhees) (map[string]interface{}, error) {
+	defer v.notify()
+
+	for i, entry := range j.Entries() {
+		if err := v.apply(entry.Operation); err != nil {
+			return nil, fmt.Errorf("[Voorhees]: Unable to replay journal entry %d. %s", i, err)
+		}
+	}
+
+	return v.result(v.JSON, nil)
+}
+
+// MarshalJSON exports the journal as an array of entries, i.e [{"op":"add","path":"a","value":1,"time":"..."}].
+func (j *Journal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Entries())
+}
+
+// UnmarshalJSON imports the entries of a journal previously exported with MarshalJSON.
+func (j *Journal) UnmarshalJSON(data []byte) error {
+	var entries []JournalEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	j.entries = entries
+	if j.now == nil {
+		j.now = time.Now
+	}
+
+	return nil
+}
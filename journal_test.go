@@ -0,0 +1,136 @@
+package voorhees
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournal(t *testing.T) {
+	journal := NewJournal()
+	journal.now = func() time.Time { return time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	v := NewVoorhees(testInput, RecordHistory())
+	v.Watch("", journal.Record)
+
+	snapshot := v.Snapshot()
+	v.Add("layer1.added", map[string]interface{}{"nested": "value"})
+	v.Add("layer1.added.later", "added afterwards")
+	v.Apply(
+		Operation{OpChange, "layer1.array[1]", "changed"},
+		Operation{Op: OpDelete, Path: "keepMe"},
+	)
+	v.Undo()
+	v.Change("layer1.doesNotExist", "fails")
+
+	expected := []JournalEntry{
+		{Operation{OpAdd, "layer1.added", map[string]interface{}{"nested": "value"}}, journal.now()},
+		{Operation{OpAdd, "layer1.added.later", "added afterwards"}, journal.now()},
+		{Operation{OpChange, "layer1.array[1]", "changed"}, journal.now()},
+		{Operation{Op: OpDelete, Path: "keepMe"}, journal.now()},
+		{Operation{OpAdd, "keepMe", "please"}, journal.now()},
+	}
+	assert.Equal(t, expected, journal.Entries(), "Expected recorded values to be unaffected by later operations")
+
+	v.Restore(snapshot)
+	assert.Len(t, journal.Entries(), 9, "Expected Restore to be recorded as the operations it performs")
+}
+
+func TestJournalExportAndReplay(t *testing.T) {
+	journal := NewJournal()
+
+	v := NewVoorhees(testInput)
+	v.Watch("", journal.Record)
+
+	v.Add("layer1.array[3].created", true)
+	v.Change("layer1.changeMe", 1.5)
+	v.Delete("layer1.array[0]")
+	v.Add(`escaped\.key`, []interface{}{"a"})
+
+	exported, err := json.Marshal(journal)
+	assert.NoError(t, err)
+
+	imported := &Journal{}
+	assert.NoError(t, json.Unmarshal(exported, imported))
+	assert.Equal(t, journal.Entries(), imported.Entries())
+
+	result, err := imported.Replay(NewVoorhees(testInput))
+	assert.NoError(t, err)
+	assert.Equal(t, v.JSON, result)
+}
+
+func TestJournalReplayBatch(t *testing.T) {
+	journal := NewJournal()
+
+	v := NewVoorhees(testInput)
+	v.Watch("", journal.Record)
+
+	v.Apply(
+		Operation{OpAdd, "added", map[string]interface{}{"x": []interface{}{1.0, 2.0}}},
+		Operation{Op: OpDelete, Path: "added.x[0]"},
+	)
+
+	result, err := journal.Replay(NewVoorhees(testInput))
+	assert.NoError(t, err)
+	assert.Equal(t, v.JSON, result, "Expected values to be recorded as they were when each operation was performed")
+}
+
+func TestJournalIsNotModifiedByReplay(t *testing.T) {
+	journal := NewJournal()
+
+	v := NewVoorhees(map[string]interface{}{})
+	v.Watch("", journal.Record)
+	v.Add("x", map[string]interface{}{"a": 1.0})
+	v.Delete("x.a")
+
+	before, err := json.Marshal(journal)
+	assert.NoError(t, err)
+
+	journal.Replay(NewVoorhees(map[string]interface{}{}))
+	journal.Entries()[0].Value.(map[string]interface{})["modified"] = true
+
+	after, err := json.Marshal(journal)
+	assert.NoError(t, err)
+	assert.Equal(t, string(before), string(after))
+}
+
+func TestJournalReplayError(t *testing.T) {
+	journal := &Journal{}
+	assert.NoError(t, json.Unmarshal([]byte(`[
+		{"op": "add", "path": "added", "value": 1, "time": "2020-01-01T00:00:00Z"},
+		{"op": "change", "path": "doesNotExist", "value": 1, "time": "2020-01-01T00:00:00Z"}
+	]`), journal))
+
+	_, err := journal.Replay(NewVoorhees(testInput))
+	assert.EqualError(t, err, "[Voorhees]: Unable to replay journal entry 1. "+
+		"[Voorhees]: Unable to change doesNotExist because it doesn't exist at path doesNotExist")
+}
+
+func TestJournalSyncVoorhees(t *testing.T) {
+	journal := NewJournal()
+
+	s := NewSyncVoorhees(copyInput(testInput))
+	s.Watch("", journal.Record)
+
+	var wg sync.WaitGroup
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				s.Apply(
+					Operation{OpChange, "layer1.changeMe", float64(w)},
+					Operation{OpAdd, indexPath("layer1.array", w), float64(i)},
+				)
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	result, err := journal.Replay(NewVoorhees(testInput))
+	assert.NoError(t, err)
+	assert.Equal(t, s.JSON(), result, "Expected the journal to record batches in the order they were applied")
+}
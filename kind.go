@@ -0,0 +1,82 @@
+package voorhees
+
+import (
+	"fmt"
+)
+
+// Kind is the JSON type of a value, named as in JSON Schema.
+type Kind string
+
+// The kinds of JSON value.
+const (
+	KindNull    Kind = "null"
+	KindBoolean Kind = "boolean"
+	KindNumber  Kind = "number"
+	KindString  Kind = "string"
+	KindObject  Kind = "object"
+	KindArray   Kind = "array"
+)
+
+// KindOf returns the JSON type of x. Numbers of any Go type are KindNumber, and maps and arrays of any type are
+// KindObject and KindArray. Values with no JSON equivalent are described by their Go type.
+func KindOf(x interface{}) Kind {
+	switch x.(type) {
+	case nil:
+		return KindNull
+	case bool:
+		return KindBoolean
+	case string:
+		return KindString
+	}
+
+	if _, isNumber := asNumber(x); isNumber {
+		return KindNumber
+	}
+
+	if _, isMap := asMap(x); isMap {
+		return KindObject
+	}
+
+	if _, isArray := asArray(x); isArray {
+		return KindArray
+	}
+
+	return Kind(fmt.Sprintf("%T", x))
+}
+
+// KindMismatchError is returned by ChangeSameType when the value provided is of a different kind to the value
+// it would replace.
+type KindMismatchError struct {
+	Path     string
+	Expected Kind
+	Actual   Kind
+}
+
+func (e *KindMismatchError) Error() string {
+	return fmt.Sprintf("[Voorhees]: Unable to change %s from %s to %s", e.Path, e.Expected, e.Actual)
+}
+
+// ChangeSameType replaces the property denoted at the end of the provided JSON path with the value provided, as
+// Change, but only when the value is of the same Kind as the property it replaces, or one of the kinds allowed,
+// i.e ChangeSameType("user.name", nil, KindNull). Otherwise a KindMismatchError is returned.
+func (v *Voorhees) ChangeSameType(path string, val interface{}, allow ...Kind) (map[string]interface{}, error) {
+	if existing, err := v.get(path); err == nil {
+		expected, actual := KindOf(existing), KindOf(val)
+
+		if expected != actual && !containsKind(allow, actual) {
+			return nil, &KindMismatchError{Path: path, Expected: expected, Actual: actual}
+		}
+	}
+
+	return v.Change(path, val) // Change reports any property that doesn't exist
+}
+
+func containsKind(kinds []Kind, kind Kind) bool {
+	for _, k := range kinds {
+		if k == kind {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,83 @@
+package voorhees
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindOf(t *testing.T) {
+	type testCase struct {
+		val      interface{}
+		expected Kind
+	}
+
+	testCases := []testCase{
+		testCase{nil, KindNull},
+		testCase{true, KindBoolean},
+		testCase{1.5, KindNumber},
+		testCase{3, KindNumber},
+		testCase{json.Number("4"), KindNumber},
+		testCase{"a", KindString},
+		testCase{map[string]interface{}{}, KindObject},
+		testCase{map[string]string{}, KindObject},
+		testCase{[]interface{}{}, KindArray},
+		testCase{[]int{1}, KindArray},
+		testCase{struct{}{}, Kind("struct {}")},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, KindOf(testCase.val), "Unexpected kind of %v", testCase.val)
+	}
+}
+
+func TestChangeSameType(t *testing.T) {
+	input := func() map[string]interface{} {
+		return map[string]interface{}{
+			"name":   "Jason",
+			"count":  1.0,
+			"active": true,
+			"nested": map[string]interface{}{"tags": []interface{}{"a"}},
+			"empty":  nil,
+		}
+	}
+
+	type testCase struct {
+		path     string
+		val      interface{}
+		allow    []Kind
+		expected string
+	}
+
+	testCases := []testCase{
+		testCase{"name", "Pamela", nil, ""},
+		testCase{"count", 2, nil, ""},
+		testCase{"nested.tags", []interface{}{}, nil, ""},
+		testCase{"nested.tags[0]", "b", nil, ""},
+		testCase{"name", nil, []Kind{KindNull}, ""},
+		testCase{"empty", "filled", []Kind{KindString, KindNumber}, ""},
+		testCase{"name", 5.0, nil, "[Voorhees]: Unable to change name from string to number"},
+		testCase{"count", "1", nil, "[Voorhees]: Unable to change count from number to string"},
+		testCase{"active", nil, []Kind{KindString}, "[Voorhees]: Unable to change active from boolean to null"},
+		testCase{"nested", []interface{}{}, nil, "[Voorhees]: Unable to change nested from object to array"},
+		testCase{"nested.tags[0]", 1.0, nil, "[Voorhees]: Unable to change nested.tags[0] from string to number"},
+		testCase{"missing", "x", nil, "[Voorhees]: Unable to change missing because it doesn't exist at path missing"},
+	}
+
+	for _, testCase := range testCases {
+		v := NewVoorhees(input())
+		_, err := v.ChangeSameType(testCase.path, testCase.val, testCase.allow...)
+
+		if testCase.expected == "" {
+			assert.NoError(t, err, "Expected change of %s to %v to be allowed", testCase.path, testCase.val)
+			continue
+		}
+
+		assert.EqualError(t, err, testCase.expected)
+		if mismatch, isMismatch := err.(*KindMismatchError); isMismatch {
+			assert.Equal(t, KindOf(testCase.val), mismatch.Actual)
+			assert.Equal(t, input(), v.JSON, "Expected a mismatched change to leave the document untouched")
+		}
+	}
+}
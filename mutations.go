@@ -0,0 +1,143 @@
+package voorhees
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MutationKind describes how a Mutation altered its source document.
+type MutationKind string
+
+const (
+	// Deleted mutations remove the node entirely.
+	Deleted MutationKind = "deleted"
+	// Nulled mutations replace the node with null.
+	Nulled MutationKind = "nulled"
+	// TypeSwapped mutations replace the node with a value of a different JSON type.
+	TypeSwapped MutationKind = "type-swapped"
+	// Emptied mutations replace a string with "" or an array with [].
+	Emptied MutationKind = "emptied"
+	// Oversized mutations replace a string with one of MutationOptions.OversizedLength characters.
+	Oversized MutationKind = "oversized"
+)
+
+// DefaultOversizedLength is the length of the strings produced by Oversized mutations when no
+// MutationOptions.OversizedLength is provided.
+const DefaultOversizedLength = 10000
+
+// MutationOptions controls which mutations are produced by Mutations.
+type MutationOptions struct {
+	// PathOptions restricts which nodes are mutated.
+	PathOptions
+
+	// Kinds restricts the mutations produced to those listed. All kinds are produced if Kinds is empty.
+	Kinds []MutationKind
+
+	// OversizedLength is the length of the strings produced by Oversized mutations.
+	// DefaultOversizedLength is used if OversizedLength is 0.
+	OversizedLength int
+}
+
+// Mutation is a single variant of a source document, produced by Mutations.
+type Mutation struct {
+	// Name identifies the mutation, and is suitable for passing to t.Run, i.e "deleted layer1.array[0]".
+	Name string
+	Path string
+	Kind MutationKind
+	JSON map[string]interface{}
+
+	// Violation describes the constraint violated by mutations produced by InvalidMutations.
+	Violation *SchemaError
+}
+
+// Mutations generates a variant of src for every applicable mutation of every node matched by opts. Each variant is
+// an independent deep copy, so they can be freely modified by the test cases that consume them.
+func Mutations(src map[string]interface{}, opts MutationOptions) []Mutation {
+	kinds := opts.Kinds
+	if len(kinds) == 0 {
+		kinds = []MutationKind{Deleted, Nulled, TypeSwapped, Emptied, Oversized}
+	}
+
+	oversizedLength := opts.OversizedLength
+	if oversizedLength == 0 {
+		oversizedLength = DefaultOversizedLength
+	}
+
+	source := NewVoorhees(src)
+	var mutations []Mutation
+
+	for _, path := range source.Paths(opts.PathOptions) {
+		value, _ := source.Get(path)
+
+		for _, kind := range kinds {
+			replacement, applicable := mutate(value, kind, oversizedLength)
+			if !applicable {
+				continue
+			}
+
+			v := NewVoorhees(source.JSON)
+			if kind == Deleted {
+				v.Delete(path)
+			} else {
+				v.Change(path, replacement)
+			}
+
+			mutations = append(mutations, Mutation{
+				Name: fmt.Sprintf("%s %s", kind, path),
+				Path: path,
+				Kind: kind,
+				JSON: v.JSON,
+			})
+		}
+	}
+
+	return mutations
+}
+
+// mutate returns the value that should replace value for the given kind of mutation, and whether that kind of
+// mutation can be applied to value at all.
+func mutate(value interface{}, kind MutationKind, oversizedLength int) (interface{}, bool) {
+	switch kind {
+	case Deleted:
+		return nil, true
+	case Nulled:
+		return nil, value != nil
+	case TypeSwapped:
+		return swapType(value)
+	case Emptied:
+		switch node := value.(type) {
+		case string:
+			return "", node != ""
+		case []interface{}:
+			return []interface{}{}, len(node) > 0
+		}
+	case Oversized:
+		if _, isString := value.(string); isString {
+			return strings.Repeat("x", oversizedLength), true
+		}
+	}
+
+	return nil, false
+}
+
+// swapType returns a value of a different JSON type to value, derived from value where possible.
+func swapType(value interface{}) (interface{}, bool) {
+	switch node := value.(type) {
+	case string:
+		if f, err := strconv.ParseFloat(node, 64); err == nil {
+			return f, true
+		}
+		return float64(len(node)), true
+	case float64:
+		return strconv.FormatFloat(node, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(node), true
+	case map[string]interface{}:
+		return []interface{}{}, true
+	case []interface{}:
+		return map[string]interface{}{}, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,85 @@
+package voorhees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMutations(t *testing.T) {
+	input := map[string]interface{}{
+		"name":  "jason",
+		"age":   13,
+		"alive": true,
+		"tags":  []interface{}{"camp"},
+	}
+
+	expected := map[string]map[string]interface{}{
+		"deleted age":      {"name": "jason", "alive": true, "tags": []interface{}{"camp"}},
+		"nulled age":       {"name": "jason", "age": nil, "alive": true, "tags": []interface{}{"camp"}},
+		"type-swapped age": {"name": "jason", "age": "13", "alive": true, "tags": []interface{}{"camp"}},
+
+		"deleted alive":      {"name": "jason", "age": float64(13), "tags": []interface{}{"camp"}},
+		"nulled alive":       {"name": "jason", "age": float64(13), "alive": nil, "tags": []interface{}{"camp"}},
+		"type-swapped alive": {"name": "jason", "age": float64(13), "alive": "true", "tags": []interface{}{"camp"}},
+
+		"deleted name":      {"age": float64(13), "alive": true, "tags": []interface{}{"camp"}},
+		"nulled name":       {"name": nil, "age": float64(13), "alive": true, "tags": []interface{}{"camp"}},
+		"type-swapped name": {"name": float64(5), "age": float64(13), "alive": true, "tags": []interface{}{"camp"}},
+		"emptied name":      {"name": "", "age": float64(13), "alive": true, "tags": []interface{}{"camp"}},
+		"oversized name":    {"name": "xxxx", "age": float64(13), "alive": true, "tags": []interface{}{"camp"}},
+
+		"deleted tags":      {"name": "jason", "age": float64(13), "alive": true},
+		"nulled tags":       {"name": "jason", "age": float64(13), "alive": true, "tags": nil},
+		"type-swapped tags": {"name": "jason", "age": float64(13), "alive": true, "tags": map[string]interface{}{}},
+		"emptied tags":      {"name": "jason", "age": float64(13), "alive": true, "tags": []interface{}{}},
+
+		"deleted tags[0]":      {"name": "jason", "age": float64(13), "alive": true, "tags": []interface{}{}},
+		"nulled tags[0]":       {"name": "jason", "age": float64(13), "alive": true, "tags": []interface{}{nil}},
+		"type-swapped tags[0]": {"name": "jason", "age": float64(13), "alive": true, "tags": []interface{}{float64(4)}},
+		"emptied tags[0]":      {"name": "jason", "age": float64(13), "alive": true, "tags": []interface{}{""}},
+		"oversized tags[0]":    {"name": "jason", "age": float64(13), "alive": true, "tags": []interface{}{"xxxx"}},
+	}
+
+	mutations := Mutations(input, MutationOptions{OversizedLength: 4})
+
+	assert.Len(t, mutations, len(expected))
+
+	for _, mutation := range mutations {
+		assert.Equal(t, expected[mutation.Name], mutation.JSON, "Unexpected result for mutation %s", mutation.Name)
+		assert.Equal(t, string(mutation.Kind)+" "+mutation.Path, mutation.Name)
+	}
+}
+
+func TestMutationsAreIndependent(t *testing.T) {
+	input := map[string]interface{}{
+		"layer1": map[string]interface{}{
+			"keepMe": "please",
+		},
+	}
+
+	mutations := Mutations(input, MutationOptions{Kinds: []MutationKind{Nulled}})
+
+	assert.Len(t, mutations, 2)
+
+	mutations[0].JSON["added"] = "uhoh"
+	assert.NotContains(t, mutations[1].JSON, "added")
+	assert.NotContains(t, input, "added")
+}
+
+func TestMutationsLeavesOnly(t *testing.T) {
+	input := map[string]interface{}{
+		"layer1": map[string]interface{}{
+			"keepMe": "please",
+		},
+	}
+
+	mutations := Mutations(input, MutationOptions{
+		PathOptions: PathOptions{LeavesOnly: true},
+		Kinds:       []MutationKind{Deleted},
+	})
+
+	assert.Len(t, mutations, 1)
+	assert.Equal(t, "deleted layer1.keepMe", mutations[0].Name)
+	assert.Equal(t, map[string]interface{}{"layer1": map[string]interface{}{}}, mutations[0].JSON)
+}
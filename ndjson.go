@@ -0,0 +1,201 @@
+package voorhees
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// NDJSONErrorMode determines how ProcessNDJSON handles lines that cannot be processed.
+type NDJSONErrorMode int
+
+const (
+	// HaltOnError stops processing at the first line that fails, returning its LineError.
+	HaltOnError NDJSONErrorMode = iota
+	// SkipErrors omits any lines that fail from the output, and carries on regardless.
+	SkipErrors
+	// CollectErrors omits any lines that fail from the output, returning a LineError for each once every line
+	// has been processed.
+	CollectErrors
+)
+
+// NDJSONOptions controls the behaviour of ProcessNDJSON.
+type NDJSONOptions struct {
+	Errors NDJSONErrorMode
+
+	// Workers is the number of lines processed in parallel. Output is always written in the order lines were
+	// read, regardless of the number of workers.
+	Workers int
+}
+
+// LineError describes a line of an NDJSON stream that could not be processed.
+type LineError struct {
+	Line int
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("line %d: %s", e.Line, e.Err)
+}
+
+// LineErrors aggregates the LineError of every line that failed when using CollectErrors.
+type LineErrors []*LineError
+
+func (e LineErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+type ndjsonLine struct {
+	number int
+	in     []byte
+	out    []byte
+	err    error
+	done   chan struct{}
+}
+
+// ProcessNDJSON applies ops to every line of a newline delimited JSON stream read from r, writing each resulting
+// document to w on a line of its own. Blank lines are ignored, and each line must be a JSON object.
+// r is never read once ProcessNDJSON has returned, so when halting on an error it first waits for any read
+// already in progress to complete.
+func ProcessNDJSON(r io.Reader, w io.Writer, ops []Operation, opts NDJSONOptions) error {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan *ndjsonLine, workers)
+	ordered := make(chan *ndjsonLine, workers*4) // bounds the number of lines held in memory at once
+	halt := make(chan struct{})
+	stopped := make(chan struct{})
+	var readErr error
+
+	go func() {
+		defer close(stopped)
+		defer close(jobs)
+		defer close(ordered)
+
+		br := bufio.NewReader(r)
+		for number := 1; ; number++ {
+			in, err := br.ReadBytes('\n')
+
+			if len(bytes.TrimSpace(in)) > 0 {
+				l := &ndjsonLine{number: number, in: in, done: make(chan struct{})}
+
+				select {
+				case ordered <- l:
+				case <-halt:
+					return
+				}
+
+				select {
+				case jobs <- l:
+				case <-halt:
+					return
+				}
+			}
+
+			if err != nil {
+				if err != io.EOF {
+					readErr = err
+				}
+				return
+			}
+		}
+	}()
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			for l := range jobs {
+				l.out, l.err = processLine(l.in, ops)
+				close(l.done)
+			}
+		}()
+	}
+
+	bw := bufio.NewWriter(w)
+	var errs LineErrors
+
+	for l := range ordered {
+		<-l.done
+
+		if l.err != nil {
+			switch opts.Errors {
+			case SkipErrors:
+			case CollectErrors:
+				errs = append(errs, &LineError{l.number, l.err})
+			default:
+				close(halt)
+				<-stopped
+				bw.Flush()
+				return &LineError{l.number, l.err}
+			}
+			continue
+		}
+
+		bw.Write(l.out)
+		bw.WriteByte('\n')
+	}
+
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+
+	if readErr != nil {
+		return readErr
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// processLine applies ops to the document on a single line. ops are shared by every line, so each line is given
+// its own copy of their values.
+func processLine(in []byte, ops []Operation) ([]byte, error) {
+	d := json.NewDecoder(bytes.NewReader(in))
+	d.UseNumber()
+
+	var doc map[string]interface{}
+	if err := d.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	if d.More() {
+		return nil, errors.New("[Voorhees]: Each line must contain a single JSON object")
+	}
+
+	if doc == nil {
+		return nil, errors.New("[Voorhees]: Each line must contain a JSON object")
+	}
+
+	copied := make([]Operation, len(ops))
+	for i, op := range ops {
+		copied[i] = op.copy()
+	}
+
+	// the document was decoded solely for this line, so there's no need for NewVoorhees to copy it
+	if _, err := (&Voorhees{JSON: doc}).Apply(copied...); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	enc := json.NewEncoder(&out)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n")), nil
+}
@@ -0,0 +1,152 @@
+package voorhees
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ndjsonInput = `{"id": 1, "user": {"name": "jason", "password": "x"}}
+{"id": 2, "user": "uhoh"}
+
+{"id": 3, "user": {"name": "pamela", "password": "y"}}
+not json
+{"id": 4, "user": {"name": "tommy", "password": "z"}}
+`
+
+var ndjsonOps = []Operation{
+	Operation{Op: OpDelete, Path: "user.password"},
+	Operation{OpAdd, "processed", true},
+}
+
+func TestProcessNDJSON(t *testing.T) {
+	type testCase struct {
+		opts          NDJSONOptions
+		expectedOut   string
+		expectedError string
+	}
+
+	testCases := []testCase{
+		testCase{
+			NDJSONOptions{},
+			`{"id":1,"processed":true,"user":{"name":"jason"}}` + "\n",
+			"line 2: [Voorhees]: Unable to navigate to user. Node: user was not a map[string]interface{}",
+		},
+		testCase{
+			NDJSONOptions{Errors: SkipErrors},
+			`{"id":1,"processed":true,"user":{"name":"jason"}}` + "\n" +
+				`{"id":3,"processed":true,"user":{"name":"pamela"}}` + "\n" +
+				`{"id":4,"processed":true,"user":{"name":"tommy"}}` + "\n",
+			"",
+		},
+		testCase{
+			NDJSONOptions{Errors: CollectErrors, Workers: 3},
+			`{"id":1,"processed":true,"user":{"name":"jason"}}` + "\n" +
+				`{"id":3,"processed":true,"user":{"name":"pamela"}}` + "\n" +
+				`{"id":4,"processed":true,"user":{"name":"tommy"}}` + "\n",
+			"line 2: [Voorhees]: Unable to navigate to user. Node: user was not a map[string]interface{}\n" +
+				"line 5: invalid character 'o' in literal null (expecting 'u')",
+		},
+	}
+
+	for _, testCase := range testCases {
+		var out bytes.Buffer
+		err := ProcessNDJSON(strings.NewReader(ndjsonInput), &out, ndjsonOps, testCase.opts)
+
+		assert.Equal(t, testCase.expectedOut, out.String())
+
+		if testCase.expectedError == "" {
+			assert.NoError(t, err)
+		} else {
+			assert.Error(t, err)
+			assert.Equal(t, testCase.expectedError, err.Error())
+		}
+	}
+}
+
+func TestProcessNDJSONPreservesOrderInParallel(t *testing.T) {
+	var in, expected strings.Builder
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(&in, `{"id": %d}`+"\n", i)
+		fmt.Fprintf(&expected, `{"id":%d,"processed":true}`+"\n", i)
+	}
+
+	var out bytes.Buffer
+	err := ProcessNDJSON(strings.NewReader(in.String()), &out, ndjsonOps[1:], NDJSONOptions{Workers: 8})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected.String(), out.String())
+}
+
+func TestProcessNDJSONGivesEachLineItsOwnValues(t *testing.T) {
+	var in, expected strings.Builder
+	for i := 0; i < 200; i++ {
+		fmt.Fprintf(&in, `{"id": %d}`+"\n", i)
+		fmt.Fprintf(&expected, `{"id":%d,"meta":{"tags":["x"]}}`+"\n", i)
+	}
+
+	ops := []Operation{
+		Operation{OpAdd, "meta", map[string]interface{}{}},
+		Operation{OpAdd, "meta.tags[0]", "x"},
+	}
+
+	var out bytes.Buffer
+	err := ProcessNDJSON(strings.NewReader(in.String()), &out, ops, NDJSONOptions{Workers: 8})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected.String(), out.String())
+	assert.Equal(t, map[string]interface{}{}, ops[0].Value)
+}
+
+func TestProcessNDJSONHaltsInParallel(t *testing.T) {
+	var in strings.Builder
+	for i := 0; i < 500; i++ {
+		fmt.Fprintf(&in, `{"id": %d}`+"\n", i)
+	}
+	in.WriteString("[]\n")
+
+	err := ProcessNDJSON(strings.NewReader(in.String()), &bytes.Buffer{}, nil, NDJSONOptions{Workers: 8})
+
+	assert.Error(t, err)
+	assert.Equal(t, 501, err.(*LineError).Line)
+}
+
+// gatedReader returns each line sent on lines from a separate Read, recording whether a Read is in progress.
+type gatedReader struct {
+	lines   chan string
+	reading int32
+}
+
+func (r *gatedReader) Read(p []byte) (int, error) {
+	atomic.StoreInt32(&r.reading, 1)
+	defer atomic.StoreInt32(&r.reading, 0)
+
+	line, ok := <-r.lines
+	if !ok {
+		return 0, io.EOF
+	}
+
+	return copy(p, line), nil
+}
+
+func TestProcessNDJSONStopsReadingWhenHalted(t *testing.T) {
+	r := &gatedReader{lines: make(chan string)}
+
+	go func() {
+		r.lines <- `{"user": "uhoh"}` + "\n"
+		time.Sleep(20 * time.Millisecond) // lets the first line fail while the next read is in progress
+		r.lines <- `{"user": {}}` + "\n"
+		close(r.lines)
+	}()
+
+	err := ProcessNDJSON(r, &bytes.Buffer{}, ndjsonOps, NDJSONOptions{})
+
+	assert.Error(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&r.reading), "Expected r to no longer be read once halted")
+}
@@ -0,0 +1,138 @@
+package voorhees
+
+import (
+	"fmt"
+)
+
+// The operations that can be described by an Operation.
+const (
+	OpAdd    = "add"
+	OpChange = "change"
+	OpDelete = "delete"
+)
+
+// Operation describes a single Add, Change or Delete, so that manipulations can be stored, generated or replayed.
+type Operation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// Apply performs each of the provided operations in turn, stopping at the first that fails.
+func (v *Voorhees) Apply(ops ...Operation) (map[string]interface{}, error) {
+	defer v.notify()
+
+	for _, op := range ops {
+		if err := v.apply(op); err != nil {
+			return nil, err
+		}
+	}
+
+	return v.result(v.JSON, nil)
+}
+
+// copy returns op with a deep copy of its value, so that applying it never shares that value with the document.
+func (op Operation) copy() Operation {
+	op.Value = clone(op.Value)
+	return op
+}
+
+func (v *Voorhees) apply(op Operation) error {
+	_, err := v.mutate(op)
+	return err
+}
+
+// mutate performs op, recording how to undo it when v has a history, and undoing it if it violates v's schema.
+func (v *Voorhees) mutate(op Operation) (map[string]interface{}, error) {
+	var undo []Operation
+	if v.history != nil || v.schema != nil {
+		undo = v.inverse(op)
+	}
+
+	queued := len(v.pending)
+	before := v.schemaErrors(op)
+
+	result, err := v.perform(op)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.enforceSchema(op, before); err != nil {
+		v.replay(undo)
+		v.pending = v.pending[:queued]
+		return nil, err
+	}
+
+	if v.history != nil {
+		// op's value is now part of the document, so a copy is kept in case it is later modified
+		v.history.record(revision{redo: []Operation{op.copy()}, undo: undo})
+	}
+
+	return result, nil
+}
+
+// applyAtomically performs ops as a single step, undoing any already performed if one of them fails.
+func (v *Voorhees) applyAtomically(ops []Operation) error {
+	var r revision
+	queued := len(v.pending)
+
+	for _, op := range ops {
+		undo := v.inverse(op)
+		before := v.schemaErrors(op)
+
+		_, err := v.perform(op)
+		if err == nil {
+			err = v.enforceSchema(op, before)
+		}
+
+		if err != nil {
+			v.replay(append(undo, r.undo...)) // undo also removes any nodes created before op failed
+			v.pending = v.pending[:queued]
+			return err
+		}
+
+		r.undo = append(undo, r.undo...)
+		if v.history != nil {
+			r.redo = append(r.redo, op.copy()) // op's value is now part of the document, so may later be modified
+		}
+	}
+
+	if v.history != nil {
+		v.history.record(r)
+	}
+
+	return nil
+}
+
+// perform carries out op against v.JSON, queueing an Event for any watchers.
+func (v *Voorhees) perform(op Operation) (map[string]interface{}, error) {
+	if v.cow {
+		v.JSON = v.prepare(v.JSON, true).(map[string]interface{}) // the root may be shared after a Fork
+	}
+
+	var old interface{}
+	if len(v.watchers) > 0 {
+		old, _ = v.get(op.Path)
+		old = clone(old) // events are delivered later, by which time old may have been modified
+	}
+
+	var result map[string]interface{}
+	var err error
+
+	switch op.Op {
+	case OpAdd:
+		result, err = v.add(op.Path, op.Value)
+	case OpChange:
+		result, err = v.change(op.Path, op.Value)
+	case OpDelete:
+		result, err = v.remove(op.Path)
+	default:
+		return nil, fmt.Errorf("[Voorhees]: Unknown operation %s for path %s", op.Op, op.Path)
+	}
+
+	if err == nil && len(v.watchers) > 0 {
+		v.queue(op, old)
+	}
+
+	return result, err
+}
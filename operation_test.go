@@ -0,0 +1,46 @@
+package voorhees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApply(t *testing.T) {
+	input := map[string]interface{}{
+		"changeMe": "please",
+		"deleteMe": "please",
+	}
+
+	expected := map[string]interface{}{
+		"changeMe": "changed",
+		"layer1": map[string]interface{}{
+			"added": "excellent",
+		},
+	}
+
+	result, err := NewVoorhees(input).Apply(
+		Operation{OpAdd, "layer1.added", "excellent"},
+		Operation{OpChange, "changeMe", "changed"},
+		Operation{Op: OpDelete, Path: "deleteMe"},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, result)
+}
+
+func TestApplyStopsAtFirstError(t *testing.T) {
+	expected := "[Voorhees]: Unknown operation move for path a"
+
+	v := NewVoorhees(map[string]interface{}{})
+	result, err := v.Apply(
+		Operation{OpAdd, "added", "excellent"},
+		Operation{"move", "a", "b"},
+		Operation{OpAdd, "notAdded", "excellent"},
+	)
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+	assert.Equal(t, expected, err.Error())
+	assert.Equal(t, map[string]interface{}{"added": "excellent"}, v.JSON)
+}
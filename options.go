@@ -0,0 +1,4 @@
+package voorhees
+
+// Option configures optional behaviour of a Voorhees instance, and can be passed to NewVoorhees.
+type Option func(*Voorhees)
@@ -9,10 +9,21 @@ type PanickerVoorhees struct {
 // except it panics instead of returning errors.
 // This is not ideomatic Go, and should never be used in production! The intended use for this struct is
 // during unit tests that require a significant number of test cases using json manipulation.
-func NewPanickerVoorhees(x map[string]interface{}) *PanickerVoorhees {
-	json := deepCopy(x)
+func NewPanickerVoorhees(x map[string]interface{}, opts ...Option) *PanickerVoorhees {
+	return &PanickerVoorhees{NewVoorhees(x, opts...)}
+}
+
+// Get returns the value of the property denoted at the end of the provided JSON path,
+// behaving exactly as NewVoorhees.Get(), expect NewPanickerVoorhees().Get()
+// panics upon encountering an error.
+func (pv *PanickerVoorhees) Get(path string) interface{} {
+	result, err := pv.v.Get(path)
+
+	if err != nil {
+		panic(err)
+	}
 
-	return &PanickerVoorhees{&Voorhees{json}}
+	return result
 }
 
 // Add creates an addition property of the provided value at path
@@ -41,6 +52,32 @@ func (pv *PanickerVoorhees) Change(path string, val interface{}) map[string]inte
 	return result
 }
 
+// ChangeSameType replaces the property denoted at the end of the provided JSON path with a value of the same kind,
+// behaving exactly as NewVoorhees.ChangeSameType(), expect NewPanickerVoorhees().ChangeSameType()
+// panics upon encountering an error.
+func (pv *PanickerVoorhees) ChangeSameType(path string, val interface{}, allow ...Kind) map[string]interface{} {
+	result, err := pv.v.ChangeSameType(path, val, allow...)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
+// Update replaces the property denoted at the end of the provided JSON path with the value derived from it by fn,
+// behaving exactly as NewVoorhees.Update(), expect NewPanickerVoorhees().Update()
+// panics upon encountering an error.
+func (pv *PanickerVoorhees) Update(path string, fn Transform) map[string]interface{} {
+	result, err := pv.v.Update(path, fn)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
+
 // Delete removes the property denoted at the end of the provided JSON path,
 // behaving exactly as NewVoorhees.Delete(), expect NewPanickerVoorhees().Delete()
 // panics upon encountering an error.
@@ -7,6 +7,24 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestPanickerGet(t *testing.T) {
+	path := "matrix[1][0].getMe"
+	input := map[string]interface{}{
+		"matrix": []interface{}{
+			[]interface{}{},
+			[]interface{}{
+				map[string]interface{}{
+					"getMe": "excellent",
+				},
+			},
+		},
+	}
+
+	result := NewPanickerVoorhees(input).Get(path)
+
+	assert.Equal(t, "excellent", result)
+}
+
 func TestPanickerAdd(t *testing.T) {
 	path := "added"
 	value := "excellent"
@@ -139,6 +157,32 @@ func TestPanickerChangeNonexistantProperty(t *testing.T) {
 	pv.Change("layer1.layer2.changeMe", "x")
 }
 
+func TestPanickerChangeSameType(t *testing.T) {
+	pv := NewPanickerVoorhees(map[string]interface{}{"count": 1.0})
+
+	assert.Equal(t, map[string]interface{}{"count": 2.0}, pv.ChangeSameType("count", 2.0))
+
+	defer func() {
+		r := recover()
+		assert.Equal(t, "[Voorhees]: Unable to change count from number to string", r.(error).Error())
+	}()
+
+	pv.ChangeSameType("count", "two")
+}
+
+func TestPanickerUpdate(t *testing.T) {
+	pv := NewPanickerVoorhees(map[string]interface{}{"count": 1.0})
+
+	assert.Equal(t, map[string]interface{}{"count": 2.0}, pv.Update("count", Increment(1)))
+
+	defer func() {
+		r := recover()
+		assert.Equal(t, "[Voorhees]: Unable to update count. Toggle requires a boolean, not number", r.(error).Error())
+	}()
+
+	pv.Update("count", Toggle())
+}
+
 func TestPanickerDeleteInvalidPath(t *testing.T) {
 	expected := "[Voorhees]: Unable to navigate to layer1.layer2.uhoh. Failed to find node: uhoh"
 
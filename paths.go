@@ -0,0 +1,60 @@
+package voorhees
+
+// PathOptions controls which paths are returned by Paths.
+type PathOptions struct {
+	// LeavesOnly excludes any maps or arrays that have children of their own.
+	LeavesOnly bool
+
+	// MaxDepth excludes any paths made up of more than MaxDepth properties and indexes, i.e a.b[0] has a depth
+	// of 3. Nodes at MaxDepth are treated as leaves. A MaxDepth of 0 means there is no limit.
+	MaxDepth int
+}
+
+// Paths returns the path of every node in the document that matches opts, in the same order as Walk.
+// Every path returned can be passed straight back into Get, Add, Change or Delete.
+func (v *Voorhees) Paths(opts PathOptions) []string {
+	var paths []string
+
+	v.Walk(func(path string, value interface{}) error {
+		atMaxDepth := opts.MaxDepth > 0 && pathDepth(path) >= opts.MaxDepth
+
+		if !opts.LeavesOnly || atMaxDepth || !hasChildren(value) {
+			paths = append(paths, path)
+		}
+
+		if atMaxDepth {
+			return SkipNode
+		}
+		return nil
+	})
+
+	return paths
+}
+
+func hasChildren(value interface{}) bool {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		return len(node) > 0
+	case []interface{}:
+		return len(node) > 0
+	}
+
+	return false
+}
+
+// pathDepth returns the number of properties and array indexes that make up path.
+func pathDepth(path string) int {
+	depth := 0
+
+	for _, prop := range splitPath(path) {
+		depth++
+
+		if denotesArray(prop) {
+			if _, arrayIndexes, err := deconstructArrayPath(prop); err == nil {
+				depth += len(arrayIndexes)
+			}
+		}
+	}
+
+	return depth
+}
@@ -0,0 +1,85 @@
+package voorhees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaths(t *testing.T) {
+	type testCase struct {
+		opts     PathOptions
+		expected []string
+	}
+
+	input := map[string]interface{}{
+		"keepMe": "please",
+		"layer1": map[string]interface{}{
+			"array": []interface{}{
+				map[string]interface{}{
+					"prop": "excellent",
+				},
+			},
+			"empty": map[string]interface{}{},
+		},
+	}
+
+	testCases := []testCase{
+		testCase{
+			PathOptions{},
+			[]string{"keepMe", "layer1", "layer1.array", "layer1.array[0]", "layer1.array[0].prop", "layer1.empty"},
+		},
+		testCase{
+			PathOptions{LeavesOnly: true},
+			[]string{"keepMe", "layer1.array[0].prop", "layer1.empty"},
+		},
+		testCase{
+			PathOptions{MaxDepth: 2},
+			[]string{"keepMe", "layer1", "layer1.array", "layer1.empty"},
+		},
+		testCase{
+			PathOptions{LeavesOnly: true, MaxDepth: 3},
+			[]string{"keepMe", "layer1.array[0]", "layer1.empty"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		result := NewVoorhees(input).Paths(testCase.opts)
+
+		assert.Equal(t, testCase.expected, result, "Expected .Paths(%+v) to return %v", testCase.opts, testCase.expected)
+	}
+}
+
+func TestPathsOfEmptyKeys(t *testing.T) {
+	v := NewVoorhees(map[string]interface{}{"": map[string]interface{}{"x": 1.0, "": []interface{}{2.0}}})
+
+	paths := v.Paths(PathOptions{})
+	assert.Equal(t, []string{`\"\"`, `\"\".\"\"`, `\"\".\"\"[0]`, `\"\".x`}, paths)
+
+	for _, path := range paths {
+		_, err := v.Get(path)
+		assert.NoError(t, err, "Expected %s to be addressable", path)
+	}
+}
+
+func TestPathsCanDeleteEachField(t *testing.T) {
+	input := map[string]interface{}{
+		"a.b": "escaped",
+		"layer1": map[string]interface{}{
+			"matrix": []interface{}{[]interface{}{"a"}},
+		},
+	}
+
+	for _, path := range NewVoorhees(input).Paths(PathOptions{LeavesOnly: true}) {
+		v := NewVoorhees(input)
+		_, err := v.Delete(path)
+
+		assert.NoError(t, err, "Expected .Delete(%s) to succeed", path)
+	}
+}
+
+func TestPathDepth(t *testing.T) {
+	assert.Equal(t, 1, pathDepth("a"))
+	assert.Equal(t, 3, pathDepth("a.b[0]"))
+	assert.Equal(t, 4, pathDepth(`a\.b.matrix[1][2]`))
+}
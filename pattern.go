@@ -0,0 +1,181 @@
+package voorhees
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// pathPattern is a compiled path in which any property may be replaced with the wildcard * and any array index
+// with [*], i.e items[*].id or meta.*.timestamp.
+type pathPattern []pathToken
+
+// pathToken is a single property or array index of a path.
+type pathToken struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func compilePattern(pattern string) (pathPattern, error) {
+	return tokenizePath(pattern, true)
+}
+
+// tokenizePath breaks path down into its individual properties and array indexes, so matrix[1][2].value becomes
+// matrix, [1], [2], value. Wildcards are only recognised when allowWildcards is set.
+func tokenizePath(path string, allowWildcards bool) ([]pathToken, error) {
+	if path == "" || path == "." {
+		return nil, nil
+	}
+
+	if path[0] == '.' {
+		path = path[1:] // tolerate a leading dot, which Add already accepts for top level properties, i.e .added
+	}
+
+	var tokens []pathToken
+	for _, prop := range splitPath(path) {
+		openingBraceIndex := indexUnescaped(prop, '[')
+		if openingBraceIndex == -1 || !denotesArray(prop) {
+			tokens = append(tokens, keyToken(prop, allowWildcards))
+			continue
+		}
+
+		tokens = append(tokens, keyToken(prop[:openingBraceIndex], allowWildcards))
+
+		for rest := prop[openingBraceIndex:]; rest != ""; {
+			closingBraceIndex := indexUnescaped(rest, ']')
+
+			if rest[0] != '[' || closingBraceIndex == -1 {
+				return nil, fmt.Errorf("[Voorhees]: Array Path | %s is not a valid array denotion", prop)
+			}
+
+			index := rest[1:closingBraceIndex]
+			rest = rest[closingBraceIndex+1:]
+
+			if index == "*" && allowWildcards {
+				tokens = append(tokens, pathToken{isIndex: true, wildcard: true})
+				continue
+			}
+
+			arrayIndex, err := strconv.Atoi(index)
+			if err != nil || arrayIndex < 0 {
+				return nil, fmt.Errorf("[Voorhees]: Array Path | %s is not a valid array denotion", prop)
+			}
+
+			tokens = append(tokens, pathToken{index: arrayIndex, isIndex: true})
+		}
+	}
+
+	return tokens, nil
+}
+
+func keyToken(prop string, allowWildcards bool) pathToken {
+	if prop == "*" && allowWildcards {
+		return pathToken{wildcard: true}
+	}
+
+	return pathToken{key: unescapeProperty(prop)}
+}
+
+// matches reports whether the pattern matches path exactly.
+func (p pathPattern) matches(path string) bool {
+	tokens, err := tokenizePath(path, false)
+
+	return err == nil && len(tokens) == len(p) && p.matchTokens(tokens)
+}
+
+// matchesSubtree reports whether the pattern matches path, or any node that path is a descendant of.
+func (p pathPattern) matchesSubtree(path string) bool {
+	tokens, err := tokenizePath(path, false)
+
+	return err == nil && len(tokens) >= len(p) && p.matchTokens(tokens[:len(p)])
+}
+
+// overlaps reports whether the pattern matches path, a node that path is a descendant of, or a node that is itself a
+// descendant of path.
+func (p pathPattern) overlaps(path string) bool {
+	tokens, err := tokenizePath(path, false)
+	if err != nil {
+		return false
+	}
+
+	if len(tokens) > len(p) {
+		tokens = tokens[:len(p)]
+	}
+
+	return p[:len(tokens)].matchTokens(tokens)
+}
+
+// intersects reports whether some node could be matched by both patterns, or by one of them and a descendant of a
+// node matched by the other.
+func (p pathPattern) intersects(q pathPattern) bool {
+	if len(q) < len(p) {
+		p, q = q, p
+	}
+
+	for i, t := range p {
+		if t.isIndex != q[i].isIndex {
+			return false
+		}
+
+		if t.wildcard || q[i].wildcard {
+			continue
+		}
+
+		if t.key != q[i].key || t.index != q[i].index {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (p pathPattern) matchTokens(tokens []pathToken) bool {
+	for i, t := range p {
+		if t.isIndex != tokens[i].isIndex {
+			return false
+		}
+
+		if t.wildcard {
+			continue
+		}
+
+		if t.key != tokens[i].key || t.index != tokens[i].index {
+			return false
+		}
+	}
+
+	return true
+}
+
+// formatPath reverses tokenizePath, producing a path that navigateToPath accepts.
+func formatPath(tokens []pathToken) string {
+	path := ""
+
+	for _, t := range tokens {
+		switch {
+		case t.isIndex && t.wildcard:
+			path += "[*]"
+		case t.isIndex:
+			path = indexPath(path, t.index)
+		case t.wildcard && path == "":
+			path = "*"
+		case t.wildcard:
+			path += ".*"
+		default:
+			path = joinPath(path, t.key)
+		}
+	}
+
+	return path
+}
+
+func (p pathPattern) hasWildcard() bool {
+	for _, t := range p {
+		if t.wildcard {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,107 @@
+package voorhees
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatternMatches(t *testing.T) {
+	type testCase struct {
+		pattern  string
+		path     string
+		expected bool
+	}
+
+	testCases := []testCase{
+		testCase{"a.b", "a.b", true},
+		testCase{"a.b", "a.c", false},
+		testCase{"a.b", "a.b.c", false},
+		testCase{"items[*].id", "items[3].id", true},
+		testCase{"items[*].id", "items[3].name", false},
+		testCase{"items[*].id", "items.id", false},
+		testCase{"*.timestamp", "meta.timestamp", true},
+		testCase{"matrix[*][1]", "matrix[0][1]", true},
+		testCase{"matrix[*][1]", "matrix[0][2]", false},
+		testCase{`a\.b.*`, `a\.b.c`, true},
+		testCase{`a\.b.*`, `a.b.c`, false},
+	}
+
+	for _, testCase := range testCases {
+		pattern, err := compilePattern(testCase.pattern)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, pattern.matches(testCase.path),
+			"Expected pattern %s matching %s to be %v", testCase.pattern, testCase.path, testCase.expected)
+	}
+}
+
+func TestPatternMatchesSubtree(t *testing.T) {
+	pattern, err := compilePattern("db")
+
+	assert.NoError(t, err)
+	assert.True(t, pattern.matchesSubtree("db"))
+	assert.True(t, pattern.matchesSubtree("db.primary.host"))
+	assert.False(t, pattern.matchesSubtree("dbs.primary"))
+
+	root, err := compilePattern("")
+
+	assert.NoError(t, err)
+	assert.True(t, root.matchesSubtree("anything[0]"))
+}
+
+func TestPatternOverlaps(t *testing.T) {
+	pattern, err := compilePattern("db.*.host")
+
+	assert.NoError(t, err)
+	assert.True(t, pattern.overlaps("db.primary.host"))
+	assert.True(t, pattern.overlaps("db.primary.host.name"))
+	assert.True(t, pattern.overlaps("db.primary"))
+	assert.True(t, pattern.overlaps(""))
+	assert.False(t, pattern.overlaps("db.primary.port"))
+	assert.False(t, pattern.overlaps("cache"))
+}
+
+func TestPatternIntersects(t *testing.T) {
+	type testCase struct {
+		a, b     string
+		expected bool
+	}
+
+	testCases := []testCase{
+		testCase{"a", "a", true},
+		testCase{"a", "a.b", true},
+		testCase{"items[*].id", "items[1]", true},
+		testCase{"db.*.host", "db.primary.host.name", true},
+		testCase{"items[*].id", "items[*].name", false},
+		testCase{"items[0]", "items.id", false},
+		testCase{"a.b", "a.c", false},
+	}
+
+	for _, testCase := range testCases {
+		a, _ := compilePattern(testCase.a)
+		b, _ := compilePattern(testCase.b)
+
+		assert.Equal(t, testCase.expected, a.intersects(b), "Expected %s intersects %s to be %v",
+			testCase.a, testCase.b, testCase.expected)
+		assert.Equal(t, testCase.expected, b.intersects(a), "Expected %s intersects %s to be %v",
+			testCase.b, testCase.a, testCase.expected)
+	}
+}
+
+func TestInvalidPattern(t *testing.T) {
+	_, err := compilePattern("items[x].id")
+
+	assert.Error(t, err)
+}
+
+func TestFormatPath(t *testing.T) {
+	paths := []string{"a", "a.b", "matrix[1][2].value", `a\.b.c\[0\]`, "items[*].*"}
+
+	for _, path := range paths {
+		tokens, err := tokenizePath(path, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, path, formatPath(tokens))
+	}
+}
@@ -0,0 +1,391 @@
+package voorhees
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Schema is a JSON Schema, supporting the subset of draft 2020-12 keywords below. Any other keywords, such as $ref,
+// are ignored. A Schema can be parsed from JSON with ParseSchema, or built directly.
+type Schema struct {
+	Type TypeList      `json:"type,omitempty"`
+	Enum []interface{} `json:"enum,omitempty"`
+
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+
+	MinLength *int   `json:"minLength,omitempty"`
+	MaxLength *int   `json:"maxLength,omitempty"`
+	Pattern   string `json:"pattern,omitempty"`
+
+	Items    *Schema `json:"items,omitempty"`
+	MinItems *int    `json:"minItems,omitempty"`
+	MaxItems *int    `json:"maxItems,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+
+	boolean *bool // set for the schemas true and false, which allow any value and no value respectively
+	pattern *regexp.Regexp
+}
+
+// TypeList holds the types allowed by a Schema, which are expressed in JSON as either a single type or an array.
+type TypeList []string
+
+// ParseSchema parses a JSON Schema, i.e {"type": "object", "required": ["name"]}.
+func ParseSchema(data []byte) (*Schema, error) {
+	s := &Schema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// BoolSchema returns the schema true, which allows any value, or false, which allows no value at all,
+// i.e AdditionalProperties: BoolSchema(false).
+func BoolSchema(allow bool) *Schema {
+	return &Schema{boolean: &allow}
+}
+
+// schemaJSON has the fields of a Schema, without its methods.
+type schemaJSON Schema
+
+// UnmarshalJSON parses a Schema, including the boolean schemas true and false, and compiles its pattern.
+func (s *Schema) UnmarshalJSON(data []byte) error {
+	var allow bool
+	if err := json.Unmarshal(data, &allow); err == nil {
+		*s = Schema{boolean: &allow}
+		return nil
+	}
+
+	if err := json.Unmarshal(data, (*schemaJSON)(s)); err != nil {
+		return err
+	}
+
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("[Voorhees]: Schema pattern %s is not a valid regular expression. %s", s.Pattern, err)
+		}
+		s.pattern = pattern
+	}
+
+	return nil
+}
+
+// MarshalJSON formats a Schema as JSON, including the boolean schemas true and false.
+func (s Schema) MarshalJSON() ([]byte, error) {
+	if s.boolean != nil {
+		return json.Marshal(*s.boolean)
+	}
+
+	return json.Marshal(schemaJSON(s))
+}
+
+// UnmarshalJSON parses either a single type or an array of types.
+func (t *TypeList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = TypeList{single}
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// MarshalJSON formats a single type as a string, and any other number of types as an array.
+func (t TypeList) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0])
+	}
+
+	return json.Marshal([]string(t))
+}
+
+// SchemaError describes a value that does not satisfy a keyword of a Schema.
+type SchemaError struct {
+	Path    string
+	Keyword string
+	Message string
+}
+
+func (e *SchemaError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf(".: %s", e.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// SchemaErrors aggregates every SchemaError found when validating a document.
+type SchemaErrors []*SchemaError
+
+func (e SchemaErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "\n")
+}
+
+func (e *SchemaErrors) add(path []pathToken, keyword, format string, args ...interface{}) {
+	*e = append(*e, &SchemaError{Path: formatPath(path), Keyword: keyword, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validate checks doc against the schema, returning SchemaErrors describing every violation, or nil if doc is valid.
+func (s *Schema) Validate(doc interface{}) error {
+	var errs SchemaErrors
+	s.validate(doc, nil, nil, &errs)
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// Validate checks the document against s, returning SchemaErrors describing every violation, or nil if it is valid.
+func (v *Voorhees) Validate(s *Schema) error {
+	return s.Validate(v.JSON)
+}
+
+// validate checks node, found at path, against the schema. When within is provided, only the descendants of node
+// along that path are validated, otherwise every descendant is.
+func (s *Schema) validate(node interface{}, path, within []pathToken, errs *SchemaErrors) {
+	if s.boolean != nil {
+		if !*s.boolean {
+			errs.add(path, "false", "no value is allowed")
+		}
+		return
+	}
+
+	if len(s.Type) > 0 && !s.Type.allows(node) {
+		errs.add(path, "type", "expected %s, got %s", strings.Join(s.Type, " or "), KindOf(node))
+		return // the remaining keywords only make sense for the expected type
+	}
+
+	if len(s.Enum) > 0 && !s.allowsEnum(node) {
+		errs.add(path, "enum", "%s is not one of %s", describeValue(node), describeValue(s.Enum))
+	}
+
+	if n, isNumber := asNumber(node); isNumber {
+		s.validateNumber(n, path, errs)
+	}
+
+	if str, isString := node.(string); isString {
+		s.validateString(str, path, errs)
+	}
+
+	if m, isMap := asMap(node); isMap {
+		s.validateObject(m, path, within, errs)
+	}
+
+	if a, isArray := asArray(node); isArray {
+		s.validateArray(a, path, within, errs)
+	}
+}
+
+func (s *Schema) validateNumber(n *big.Rat, path []pathToken, errs *SchemaErrors) {
+	bounds := []struct {
+		bound   *float64
+		keyword string
+		valid   func(cmp int) bool
+		format  string
+	}{
+		{s.Minimum, "minimum", func(cmp int) bool { return cmp >= 0 }, "must be at least %v"},
+		{s.Maximum, "maximum", func(cmp int) bool { return cmp <= 0 }, "must be at most %v"},
+		{s.ExclusiveMinimum, "exclusiveMinimum", func(cmp int) bool { return cmp > 0 }, "must be greater than %v"},
+		{s.ExclusiveMaximum, "exclusiveMaximum", func(cmp int) bool { return cmp < 0 }, "must be less than %v"},
+	}
+
+	for _, b := range bounds {
+		if b.bound != nil && !b.valid(n.Cmp(new(big.Rat).SetFloat64(*b.bound))) {
+			errs.add(path, b.keyword, b.format, *b.bound)
+		}
+	}
+}
+
+func (s *Schema) validateString(str string, path []pathToken, errs *SchemaErrors) {
+	length := utf8.RuneCountInString(str)
+
+	if s.MinLength != nil && length < *s.MinLength {
+		errs.add(path, "minLength", "must be at least %d characters long", *s.MinLength)
+	}
+
+	if s.MaxLength != nil && length > *s.MaxLength {
+		errs.add(path, "maxLength", "must be at most %d characters long", *s.MaxLength)
+	}
+
+	if s.Pattern != "" {
+		pattern := s.pattern
+		if pattern == nil { // the schema was not parsed, so the pattern hasn't been compiled
+			var err error
+			if pattern, err = regexp.Compile(s.Pattern); err != nil {
+				errs.add(path, "pattern", "pattern %s is not a valid regular expression", s.Pattern)
+				return
+			}
+		}
+
+		if !pattern.MatchString(str) {
+			errs.add(path, "pattern", "must match the pattern %s", s.Pattern)
+		}
+	}
+}
+
+func (s *Schema) validateObject(m map[string]interface{}, path, within []pathToken, errs *SchemaErrors) {
+	for _, required := range s.Required {
+		if _, exists := m[required]; !exists {
+			errs.add(append(path[:len(path):len(path)], pathToken{key: required}), "required", "is required")
+		}
+	}
+
+	keys := sortedKeys(m)
+	if len(within) > 0 {
+		keys = nil
+		if _, exists := m[within[0].key]; exists && !within[0].isIndex {
+			keys = []string{within[0].key}
+		}
+		within = within[1:]
+	}
+
+	for _, k := range keys {
+		childPath := append(path[:len(path):len(path)], pathToken{key: k})
+
+		child, isProperty := s.Properties[k]
+		if !isProperty {
+			child = s.AdditionalProperties
+		}
+
+		switch {
+		case child == nil:
+		case !isProperty && child.boolean != nil && !*child.boolean:
+			errs.add(childPath, "additionalProperties", "is not an allowed property")
+		default:
+			child.validate(m[k], childPath, within, errs)
+		}
+	}
+}
+
+func (s *Schema) validateArray(a []interface{}, path, within []pathToken, errs *SchemaErrors) {
+	if s.MinItems != nil && len(a) < *s.MinItems {
+		errs.add(path, "minItems", "must have at least %d items", *s.MinItems)
+	}
+
+	if s.MaxItems != nil && len(a) > *s.MaxItems {
+		errs.add(path, "maxItems", "must have at most %d items", *s.MaxItems)
+	}
+
+	if s.Items == nil {
+		return
+	}
+
+	first, last := 0, len(a)
+	if len(within) > 0 {
+		first, last = 0, 0
+		if within[0].isIndex && within[0].index < len(a) {
+			first, last = within[0].index, within[0].index+1
+		}
+		within = within[1:]
+	}
+
+	for i := first; i < last; i++ {
+		s.Items.validate(a[i], append(path[:len(path):len(path)], pathToken{index: i, isIndex: true}), within, errs)
+	}
+}
+
+func (s *Schema) allowsEnum(node interface{}) bool {
+	for _, allowed := range s.Enum {
+		if len(Diff(node, allowed)) == 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// allows reports whether x is of any of the types in the list.
+func (t TypeList) allows(x interface{}) bool {
+	kind := string(KindOf(x))
+
+	for _, allowed := range t {
+		if allowed == kind {
+			return true
+		}
+
+		if n, isNumber := asNumber(x); isNumber && allowed == "integer" && n.IsInt() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// EnforceSchema rejects any Add, Change or Delete that would leave the document invalid against s, returning an
+// InvalidOperationError and leaving the document as it was. Only the violations an operation introduces cause it to
+// be rejected, so a document that is already invalid can still be corrected one operation at a time.
+func EnforceSchema(s *Schema) Option {
+	return func(v *Voorhees) {
+		v.schema = s
+	}
+}
+
+// InvalidOperationError is returned when an operation is rejected because of the Schema passed to EnforceSchema.
+type InvalidOperationError struct {
+	Operation Operation
+	Errors    SchemaErrors
+}
+
+func (e *InvalidOperationError) Error() string {
+	return fmt.Sprintf("[Voorhees]: Unable to %s %s because it would make the document invalid. %s",
+		e.Operation.Op, e.Operation.Path, e.Errors)
+}
+
+// schemaErrors validates the nodes affected by op against v's schema, returning any violations found.
+func (v *Voorhees) schemaErrors(op Operation) SchemaErrors {
+	if v.schema == nil {
+		return nil
+	}
+
+	tokens, _ := tokenizePath(op.Path, false)
+	if len(tokens) > 0 && tokens[len(tokens)-1].isIndex {
+		tokens = tokens[:len(tokens)-1] // the array may have grown or shrunk, so validate all of it
+	}
+
+	var errs SchemaErrors
+	v.schema.validate(v.JSON, nil, tokens, &errs)
+
+	return errs
+}
+
+// enforceSchema validates the nodes affected by op, once it has been performed, failing if op introduced any
+// violations beyond those found by schemaErrors before it was performed.
+func (v *Voorhees) enforceSchema(op Operation, before SchemaErrors) error {
+	existing := make(map[SchemaError]int, len(before))
+	for _, err := range before {
+		existing[*err]++
+	}
+
+	var introduced SchemaErrors
+	for _, err := range v.schemaErrors(op) {
+		if existing[*err] > 0 {
+			existing[*err]--
+			continue
+		}
+		introduced = append(introduced, err)
+	}
+
+	if len(introduced) > 0 {
+		return &InvalidOperationError{Operation: op, Errors: introduced}
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package voorhees
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["name", "age", "tags"],
+	"additionalProperties": false,
+	"properties": {
+		"name": {"type": "string", "minLength": 1, "maxLength": 10, "pattern": "^[A-Z]"},
+		"age": {"type": "integer", "minimum": 0, "exclusiveMaximum": 150},
+		"score": {"type": ["number", "null"], "maximum": 1, "exclusiveMinimum": 0},
+		"role": {"enum": ["admin", "user", 1]},
+		"tags": {"type": "array", "minItems": 1, "maxItems": 3, "items": {"type": "string"}},
+		"address": {
+			"type": "object",
+			"required": ["city"],
+			"properties": {"city": {"type": "string"}},
+			"additionalProperties": {"type": "string"}
+		}
+	}
+}`
+
+func parseTestSchema(t *testing.T) *Schema {
+	s, err := ParseSchema([]byte(testSchema))
+	assert.NoError(t, err)
+
+	return s
+}
+
+func TestValidate(t *testing.T) {
+	type testCase struct {
+		path     string
+		val      interface{}
+		expected SchemaErrors
+	}
+
+	testCases := []testCase{
+		testCase{"name", "Pamela", nil},
+		testCase{"score", nil, nil},
+		testCase{"score", 0.5, nil},
+		testCase{"role", 1, nil},
+		testCase{"age", json.Number("12"), nil},
+		testCase{"name", 5.0, SchemaErrors{{"name", "type", "expected string, got number"}}},
+		testCase{"name", "", SchemaErrors{
+			{"name", "minLength", "must be at least 1 characters long"},
+			{"name", "pattern", "must match the pattern ^[A-Z]"},
+		}},
+		testCase{"name", "Crystal–Åsgård", SchemaErrors{{"name", "maxLength", "must be at most 10 characters long"}}},
+		testCase{"age", 40.5, SchemaErrors{{"age", "type", "expected integer, got number"}}},
+		testCase{"age", -1, SchemaErrors{{"age", "minimum", "must be at least 0"}}},
+		testCase{"age", 150, SchemaErrors{{"age", "exclusiveMaximum", "must be less than 150"}}},
+		testCase{"score", 0, SchemaErrors{{"score", "exclusiveMinimum", "must be greater than 0"}}},
+		testCase{"score", 1.5, SchemaErrors{{"score", "maximum", "must be at most 1"}}},
+		testCase{"score", "high", SchemaErrors{{"score", "type", "expected number or null, got string"}}},
+		testCase{"role", "guest", SchemaErrors{{"role", "enum", `"guest" is not one of ["admin","user",1]`}}},
+		testCase{"tags", []interface{}{}, SchemaErrors{{"tags", "minItems", "must have at least 1 items"}}},
+		testCase{"tags", []interface{}{"a", "b", "c", "d"}, SchemaErrors{{"tags", "maxItems", "must have at most 3 items"}}},
+		testCase{"tags[1]", true, SchemaErrors{{"tags[1]", "type", "expected string, got boolean"}}},
+		testCase{"address.zip", 12345, SchemaErrors{{"address.zip", "type", "expected string, got number"}}},
+		testCase{"address", map[string]interface{}{}, SchemaErrors{{"address.city", "required", "is required"}}},
+		testCase{"nickname", "Jay", SchemaErrors{{"nickname", "additionalProperties", "is not an allowed property"}}},
+	}
+
+	s := parseTestSchema(t)
+	for _, testCase := range testCases {
+		v := NewVoorhees(schemaInput)
+		v.Add(testCase.path, testCase.val)
+
+		err := v.Validate(s)
+		if testCase.expected == nil {
+			assert.NoError(t, err, "Expected %s of %v to be valid", testCase.path, testCase.val)
+			continue
+		}
+
+		assert.Equal(t, testCase.expected, err, "Unexpected errors adding %s of %v", testCase.path, testCase.val)
+	}
+}
+
+func TestValidateReportsEveryError(t *testing.T) {
+	v := NewVoorhees(map[string]interface{}{"tags": []interface{}{1.0, "ok", nil}})
+
+	err := v.Validate(parseTestSchema(t))
+
+	assert.EqualError(t, err, "name: is required\n"+
+		"age: is required\n"+
+		"tags[0]: expected string, got number\n"+
+		"tags[2]: expected string, got null")
+}
+
+func TestSchemaJSON(t *testing.T) {
+	s := parseTestSchema(t)
+
+	marshalled, err := json.Marshal(s)
+	assert.NoError(t, err)
+
+	reparsed, err := ParseSchema(marshalled)
+	assert.NoError(t, err)
+	assert.Equal(t, s, reparsed)
+	assert.Contains(t, string(marshalled), `"additionalProperties":false`)
+	assert.Contains(t, string(marshalled), `"type":["number","null"]`)
+
+	_, err = ParseSchema([]byte(`{"pattern": "["}`))
+	assert.Error(t, err)
+}
+
+func TestBuiltSchema(t *testing.T) {
+	min := 1
+	s := &Schema{
+		Type:                 TypeList{"object"},
+		Properties:           map[string]*Schema{"name": {Type: TypeList{"string"}, MinLength: &min, Pattern: "^J"}},
+		AdditionalProperties: BoolSchema(true),
+	}
+
+	assert.NoError(t, s.Validate(map[string]interface{}{"name": "Jason", "other": 1}))
+	assert.EqualError(t, s.Validate(map[string]interface{}{"name": "Pamela"}), "name: must match the pattern ^J")
+	assert.EqualError(t, BoolSchema(false).Validate(nil), ".: no value is allowed")
+}
+
+func TestEnforceSchema(t *testing.T) {
+	type testCase struct {
+		op       Operation
+		expected string
+	}
+
+	testCases := []testCase{
+		testCase{Operation{OpChange, "age", "forty"},
+			"[Voorhees]: Unable to change age because it would make the document invalid. age: expected integer, got string"},
+		testCase{Operation{Op: OpDelete, Path: "name"},
+			"[Voorhees]: Unable to delete name because it would make the document invalid. name: is required"},
+		testCase{Operation{OpAdd, "extra.nested", true},
+			"[Voorhees]: Unable to add extra.nested because it would make the document invalid. extra: is not an allowed property"},
+		testCase{Operation{OpAdd, "tags[5]", "grown"},
+			"[Voorhees]: Unable to add tags[5] because it would make the document invalid. " +
+				"tags: must have at most 3 items\ntags[2]: expected string, got null\n" +
+				"tags[3]: expected string, got null\ntags[4]: expected string, got null"},
+		testCase{Operation{Op: OpDelete, Path: "address.city"},
+			"[Voorhees]: Unable to delete address.city because it would make the document invalid. address.city: is required"},
+	}
+
+	for _, testCase := range testCases {
+		for _, opts := range [][]Option{{EnforceSchema(parseTestSchema(t))}, {EnforceSchema(parseTestSchema(t)), CopyOnWrite()}} {
+			v := NewVoorhees(copyInput(schemaInput), opts...)
+
+			var events []Event
+			v.Watch("", func(e Event) { events = append(events, e) })
+
+			_, err := v.Apply(testCase.op)
+
+			assert.EqualError(t, err, testCase.expected)
+			_, isInvalid := err.(*InvalidOperationError)
+			assert.True(t, isInvalid)
+			assert.Equal(t, schemaInput, v.JSON, "Expected a rejected %s of %s to leave the document untouched",
+				testCase.op.Op, testCase.op.Path)
+			assert.Empty(t, events, "Expected no events for a rejected operation")
+		}
+	}
+}
+
+func TestEnforceSchemaAllowsValidOperations(t *testing.T) {
+	v := NewVoorhees(schemaInput, EnforceSchema(parseTestSchema(t)), RecordHistory())
+
+	_, err := v.Apply(
+		Operation{OpChange, "name", "Pamela"},
+		Operation{OpAdd, "tags[2]", "mother"},
+		Operation{Op: OpDelete, Path: "tags[0]"},
+		Operation{OpAdd, "address.zip", "07825"},
+		Operation{Op: OpDelete, Path: "address.street"},
+	)
+	assert.NoError(t, err)
+
+	v.JSON["invalid"] = true // a violation that isn't along the path of later operations
+	_, err = v.Change("age", 41.0)
+	assert.NoError(t, err)
+
+	_, err = v.Undo()
+	assert.NoError(t, err)
+	assert.Equal(t, 40.0, v.JSON["age"])
+}
+
+func TestEnforceSchemaIgnoresExistingViolations(t *testing.T) {
+	v := NewVoorhees(schemaInput, EnforceSchema(parseTestSchema(t)))
+	delete(v.JSON, "tags")                                    // required of the root, so checked by every operation
+	v.JSON["address"].(map[string]interface{})["city"] = 13.0 // along the path of the later operations
+
+	_, err := v.Apply(
+		Operation{OpChange, "age", 41.0},
+		Operation{OpAdd, "address.zip", "07825"},
+	)
+	assert.NoError(t, err)
+
+	_, err = v.Change("address.street", 1.0)
+	assert.EqualError(t, err, "[Voorhees]: Unable to change address.street because it would make the document invalid. "+
+		"address.street: expected string, got number")
+
+	_, err = v.Change("address.city", "Crystal Lake")
+	assert.NoError(t, err, "Expected an operation that fixes an existing violation to be allowed")
+}
+
+func TestEnforceSchemaSyncVoorhees(t *testing.T) {
+	s := NewSyncVoorhees(copyInput(schemaInput), EnforceSchema(parseTestSchema(t)))
+
+	_, err := s.Apply(Operation{OpChange, "name", "Pamela"}, Operation{OpChange, "age", -1})
+
+	_, isInvalid := err.(*InvalidOperationError)
+	assert.True(t, isInvalid)
+	assert.Equal(t, schemaInput, s.JSON())
+}
@@ -0,0 +1,350 @@
+package voorhees
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// StreamEditor applies operations to a JSON document as it streams from an io.Reader to an io.Writer, without
+// ever holding the whole document in memory. This makes it suitable for documents far too large for NewVoorhees.
+//
+// As the document is never fully decoded, a StreamEditor behaves slightly differently to Apply:
+//   - each node may be targeted by only one operation, so no path may match the same node as another, or a node
+//     beneath it, i.e Delete a followed by Add a.b is rejected by NewStreamEditor;
+//   - Change and Delete paths may contain wildcards, where * matches any property and [*] any array index;
+//   - Add creates any missing nodes when the deepest existing node along its path has been read, appending
+//     properties to maps and padding arrays with nulls as required;
+//   - Delete silently ignores paths that do not exist, while Change fails if its path is never found;
+//   - output is compact JSON, and any output written before an error is encountered is not retracted.
+type StreamEditor struct {
+	ops      []Operation
+	patterns []pathPattern
+}
+
+// NewStreamEditor compiles the provided operations into a StreamEditor, failing if any of them are invalid.
+func NewStreamEditor(ops ...Operation) (*StreamEditor, error) {
+	e := &StreamEditor{ops: ops, patterns: make([]pathPattern, len(ops))}
+
+	for i, op := range ops {
+		pattern, err := compilePattern(op.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case op.Op != OpAdd && op.Op != OpChange && op.Op != OpDelete:
+			return nil, fmt.Errorf("[Voorhees]: Unknown operation %s for path %s", op.Op, op.Path)
+		case len(pattern) == 0:
+			return nil, fmt.Errorf("[Voorhees]: Unable to %s the root of a document", op.Op)
+		case op.Op == OpAdd && pattern.hasWildcard():
+			return nil, fmt.Errorf("[Voorhees]: Unable to add %s, as paths to add cannot contain wildcards", op.Path)
+		}
+
+		for j, other := range e.patterns[:i] {
+			if pattern.intersects(other) {
+				return nil, fmt.Errorf("[Voorhees]: Unable to %s %s and %s %s, as each node may only be targeted by "+
+					"one operation when streaming", ops[j].Op, ops[j].Path, op.Op, op.Path)
+			}
+		}
+
+		e.patterns[i] = pattern
+	}
+
+	return e, nil
+}
+
+// Edit reads a single JSON object from r, writing it to w with every operation applied.
+func (e *StreamEditor) Edit(r io.Reader, w io.Writer) error {
+	dec := json.NewDecoder(r)
+	dec.UseNumber()
+
+	s := &stream{
+		editor:   e,
+		resolved: make([]bool, len(e.ops)),
+		dec:      dec,
+		w:        bufio.NewWriter(w),
+	}
+
+	if err := s.run(); err != nil {
+		if err == io.EOF && len(s.stack) > 0 {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	return s.w.Flush()
+}
+
+// stream holds the state of a single StreamEditor.Edit.
+type stream struct {
+	editor   *StreamEditor
+	resolved []bool
+	dec      *json.Decoder
+	w        *bufio.Writer
+	stack    []*streamFrame
+}
+
+// streamFrame tracks a map or array that has been opened but not yet closed.
+type streamFrame struct {
+	path    []pathToken
+	isArray bool
+	index   int // the index of the next element of an array to be read
+	written int // the number of properties or elements written so far
+}
+
+func (s *stream) run() error {
+	token, err := s.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	if token != json.Delim('{') {
+		return errors.New("[Voorhees]: Streamed documents must be JSON objects")
+	}
+
+	s.w.WriteByte('{')
+	s.stack = []*streamFrame{&streamFrame{}}
+
+	for len(s.stack) > 0 {
+		f := s.stack[len(s.stack)-1]
+
+		if !s.dec.More() {
+			if err := s.close(f); err != nil {
+				return err
+			}
+			continue
+		}
+
+		key := ""
+		path := make([]pathToken, len(f.path), len(f.path)+1)
+		copy(path, f.path)
+
+		if f.isArray {
+			path = append(path, pathToken{index: f.index, isIndex: true})
+			f.index++
+		} else {
+			token, err := s.dec.Token()
+			if err != nil {
+				return err
+			}
+			key = token.(string)
+			path = append(path, pathToken{key: key})
+		}
+
+		if err := s.value(f, path, key); err != nil {
+			return err
+		}
+	}
+
+	for i, op := range s.editor.ops {
+		if op.Op == OpChange && !s.resolved[i] && !s.editor.patterns[i].hasWildcard() {
+			return fmt.Errorf("[Voorhees]: Unable to change %s because it doesn't exist", op.Path)
+		}
+	}
+
+	return nil
+}
+
+// value handles the next value in the stream, found at path inside f.
+func (s *stream) value(f *streamFrame, path []pathToken, key string) error {
+	for i, op := range s.editor.ops {
+		if len(s.editor.patterns[i]) != len(path) || !s.editor.patterns[i].matchTokens(path) {
+			continue
+		}
+
+		s.resolved[i] = true
+		if err := s.skip(); err != nil {
+			return err
+		}
+
+		if op.Op == OpDelete {
+			return nil
+		}
+
+		return s.write(f, key, op.Value)
+	}
+
+	token, err := s.dec.Token()
+	if err != nil {
+		return err
+	}
+
+	s.separate(f, key)
+
+	switch token {
+	case json.Delim('{'):
+		s.w.WriteByte('{')
+		s.stack = append(s.stack, &streamFrame{path: path})
+	case json.Delim('['):
+		s.w.WriteByte('[')
+		s.stack = append(s.stack, &streamFrame{path: path, isArray: true})
+	default:
+		if err := s.addsBeneath(path); err != nil {
+			return err
+		}
+		return s.encode(token)
+	}
+
+	return nil
+}
+
+// close consumes the end of f from the stream, adding anything that belongs inside it before it is written.
+func (s *stream) close(f *streamFrame) error {
+	if _, err := s.dec.Token(); err != nil {
+		return err
+	}
+
+	if err := s.addMissing(f); err != nil {
+		return err
+	}
+
+	if f.isArray {
+		s.w.WriteByte(']')
+	} else {
+		s.w.WriteByte('}')
+	}
+
+	s.stack = s.stack[:len(s.stack)-1]
+	return nil
+}
+
+// addMissing writes any unresolved adds whose path continues beyond f into nodes that f does not contain.
+func (s *stream) addMissing(f *streamFrame) error {
+	missing := &Voorhees{JSON: map[string]interface{}{}}
+	found := false
+
+	for i, op := range s.editor.ops {
+		pattern := s.editor.patterns[i]
+
+		if op.Op != OpAdd || s.resolved[i] || len(pattern) <= len(f.path) || !pattern[:len(f.path)].matchTokens(f.path) {
+			continue
+		}
+
+		// had f contained the next node along the path, the add would have been resolved or rejected as it was read
+		next := pattern[len(f.path)]
+
+		switch {
+		case next.isIndex && !f.isArray:
+			return fmt.Errorf("[Voorhees]: Unable to add %s. Node: %s was not an array", op.Path, formatPath(f.path))
+		case !next.isIndex && f.isArray:
+			return fmt.Errorf("[Voorhees]: Unable to add %s. Node: %s was not a map", op.Path, formatPath(f.path))
+		}
+
+		relative := pattern[len(f.path):]
+		if f.isArray {
+			relative = append([]pathToken{pathToken{key: "elements"}}, relative...)
+		}
+
+		if _, err := missing.Add(formatPath(relative), op.Value); err != nil {
+			return err
+		}
+
+		s.resolved[i] = true
+		found = true
+	}
+
+	if !found {
+		return nil
+	}
+
+	if f.isArray {
+		elements := missing.JSON["elements"].([]interface{})
+		for _, elem := range elements[f.index:] {
+			if err := s.write(f, "", elem); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	keys := make([]string, 0, len(missing.JSON))
+	for k := range missing.JSON {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := s.write(f, k, missing.JSON[k]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// addsBeneath fails if any add passes through the scalar at path, which cannot contain the node being added.
+func (s *stream) addsBeneath(path []pathToken) error {
+	for i, op := range s.editor.ops {
+		pattern := s.editor.patterns[i]
+
+		if op.Op == OpAdd && len(pattern) > len(path) && pattern[:len(path)].matchTokens(path) {
+			return fmt.Errorf("[Voorhees]: Unable to add %s. Node: %s was not a map or array", op.Path, formatPath(path))
+		}
+	}
+
+	return nil
+}
+
+// skip consumes the next value in the stream without writing it.
+func (s *stream) skip() error {
+	for depth := 0; ; {
+		token, err := s.dec.Token()
+		if err != nil {
+			return err
+		}
+
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// separate writes whatever is required before the next property or element of f.
+func (s *stream) separate(f *streamFrame, key string) {
+	if f.written > 0 {
+		s.w.WriteByte(',')
+	}
+	f.written++
+
+	if !f.isArray {
+		s.encode(key)
+		s.w.WriteByte(':')
+	}
+}
+
+// write writes val as the next property or element of f.
+func (s *stream) write(f *streamFrame, key string, val interface{}) error {
+	s.separate(f, key)
+
+	return s.encode(val)
+}
+
+func (s *stream) encode(val interface{}) error {
+	if n, isNumber := val.(json.Number); isNumber {
+		s.w.WriteString(n.String())
+		return nil
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(val); err != nil {
+		return err
+	}
+
+	s.w.Write(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+	return nil
+}
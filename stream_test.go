@@ -0,0 +1,211 @@
+package voorhees
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const streamInput = `{
+  "id": 12345678901234567890,
+  "html": "<b>&</b>",
+  "user": {"name": "jason", "email": "jason@crystal.lake"},
+  "items": [
+    {"id": "a", "secret": 1},
+    {"id": "b", "secret": 2}
+  ],
+  "matrix": [[1, 2], [3]]
+}`
+
+func TestStreamEditor(t *testing.T) {
+	type testCase struct {
+		ops      []Operation
+		expected string
+	}
+
+	testCases := []testCase{
+		testCase{
+			nil,
+			`{"id":12345678901234567890,"html":"<b>&</b>","user":{"name":"jason","email":"jason@crystal.lake"},` +
+				`"items":[{"id":"a","secret":1},{"id":"b","secret":2}],"matrix":[[1,2],[3]]}`,
+		},
+		testCase{
+			[]Operation{
+				Operation{OpChange, "user.name", "pamela"},
+				Operation{Op: OpDelete, Path: "items[*].secret"},
+				Operation{Op: OpDelete, Path: "html"},
+				Operation{Op: OpDelete, Path: "matrix[0][0]"},
+			},
+			`{"id":12345678901234567890,"user":{"name":"pamela","email":"jason@crystal.lake"},` +
+				`"items":[{"id":"a"},{"id":"b"}],"matrix":[[2],[3]]}`,
+		},
+		testCase{
+			[]Operation{
+				Operation{OpChange, "items", []interface{}{}},
+				Operation{OpAdd, "user.age", 13},
+				Operation{OpAdd, "user.name", "pamela"},
+				Operation{OpAdd, "matrix[1][2]", 5},
+				Operation{OpAdd, "new.nested[1].prop", true},
+				Operation{OpAdd, "another", "added"},
+			},
+			`{"id":12345678901234567890,"html":"<b>&</b>","user":{"name":"pamela","email":"jason@crystal.lake",` +
+				`"age":13},"items":[],"matrix":[[1,2],[3,null,5]],"another":"added",` +
+				`"new":{"nested":[null,{"prop":true}]}}`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		e, err := NewStreamEditor(testCase.ops...)
+		assert.NoError(t, err)
+
+		var out bytes.Buffer
+		err = e.Edit(strings.NewReader(streamInput), &out)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, out.String())
+	}
+}
+
+func TestStreamEditorMatchesApply(t *testing.T) {
+	ops := []Operation{
+		Operation{OpChange, "user.email", "pamela@crystal.lake"},
+		Operation{OpAdd, "user.address.city", "crystal lake"},
+		Operation{OpAdd, "items[2].id", "c"},
+		Operation{Op: OpDelete, Path: "items[0].secret"},
+	}
+
+	e, err := NewStreamEditor(ops...)
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	assert.NoError(t, e.Edit(strings.NewReader(streamInput), &out))
+
+	streamed, err := decodeDocument(out.Bytes())
+	assert.NoError(t, err)
+
+	src, err := decodeDocument([]byte(streamInput))
+	assert.NoError(t, err)
+
+	v := &Voorhees{JSON: src} // NewVoorhees would lose the precision of id when copying src
+	expected, err := v.Apply(ops...)
+	assert.NoError(t, err)
+
+	equal, diffs := Equal(expected, streamed)
+	assert.True(t, equal, "Expected streamed result to match Apply: %v", diffs)
+}
+
+func TestStreamEditorErrors(t *testing.T) {
+	type testCase struct {
+		ops      []Operation
+		input    string
+		expected string
+	}
+
+	testCases := []testCase{
+		testCase{
+			[]Operation{Operation{OpChange, "user.uhoh", "x"}},
+			streamInput,
+			"[Voorhees]: Unable to change user.uhoh because it doesn't exist",
+		},
+		testCase{
+			[]Operation{Operation{OpAdd, "user.name.uhoh", "x"}},
+			streamInput,
+			"[Voorhees]: Unable to add user.name.uhoh. Node: user.name was not a map or array",
+		},
+		testCase{
+			[]Operation{Operation{OpAdd, "items.uhoh", "x"}},
+			streamInput,
+			"[Voorhees]: Unable to add items.uhoh. Node: items was not a map",
+		},
+		testCase{
+			[]Operation{Operation{OpAdd, "items[1].secret.uhoh", "x"}},
+			streamInput,
+			"[Voorhees]: Unable to add items[1].secret.uhoh. Node: items[1].secret was not a map or array",
+		},
+		testCase{
+			[]Operation{Operation{OpAdd, "matrix[0][1].uhoh", "x"}},
+			streamInput,
+			"[Voorhees]: Unable to add matrix[0][1].uhoh. Node: matrix[0][1] was not a map or array",
+		},
+		testCase{
+			nil,
+			`["not", "an", "object"]`,
+			"[Voorhees]: Streamed documents must be JSON objects",
+		},
+		testCase{
+			nil,
+			`{"user": `,
+			"unexpected EOF",
+		},
+	}
+
+	for _, testCase := range testCases {
+		e, err := NewStreamEditor(testCase.ops...)
+		assert.NoError(t, err)
+
+		err = e.Edit(strings.NewReader(testCase.input), &bytes.Buffer{})
+
+		assert.Error(t, err)
+		assert.Equal(t, testCase.expected, err.Error())
+	}
+}
+
+func TestNewStreamEditorInvalidOperations(t *testing.T) {
+	invalid := []Operation{
+		Operation{OpAdd, "items[*].id", "x"},
+		Operation{"move", "a", "b"},
+		Operation{Op: OpDelete, Path: ""},
+		Operation{Op: OpDelete, Path: "items[x]"},
+	}
+
+	for _, op := range invalid {
+		_, err := NewStreamEditor(op)
+
+		assert.Error(t, err, "Expected %+v to be invalid", op)
+	}
+}
+
+func TestNewStreamEditorOverlappingOperations(t *testing.T) {
+	type testCase struct {
+		ops      []Operation
+		expected string
+	}
+
+	testCases := []testCase{
+		testCase{
+			[]Operation{Operation{OpChange, "user.name", "a"}, Operation{OpChange, "user.name", "b"}},
+			"[Voorhees]: Unable to change user.name and change user.name, " +
+				"as each node may only be targeted by one operation when streaming",
+		},
+		testCase{
+			[]Operation{Operation{Op: OpDelete, Path: "user"}, Operation{OpAdd, "user.name", "pamela"}},
+			"[Voorhees]: Unable to delete user and add user.name, " +
+				"as each node may only be targeted by one operation when streaming",
+		},
+		testCase{
+			[]Operation{Operation{OpAdd, "items[1].tag", "x"}, Operation{Op: OpDelete, Path: "items[*]"}},
+			"[Voorhees]: Unable to add items[1].tag and delete items[*], " +
+				"as each node may only be targeted by one operation when streaming",
+		},
+	}
+
+	for _, testCase := range testCases {
+		e, err := NewStreamEditor(testCase.ops...)
+
+		assert.Nil(t, e)
+		assert.EqualError(t, err, testCase.expected)
+	}
+}
+
+func decodeDocument(b []byte) (map[string]interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+
+	var doc map[string]interface{}
+	err := d.Decode(&doc)
+
+	return doc, err
+}
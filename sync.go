@@ -0,0 +1,145 @@
+package voorhees
+
+import (
+	"sync"
+)
+
+// SyncVoorhees is a Voorhees instance that can be shared between goroutines, i.e a document read by many request
+// handlers while being edited elsewhere. Each manipulation is applied atomically, so readers never observe a
+// partially applied change.
+// SyncVoorhees always operates in CopyOnWrite mode: the maps returned by each method are never modified by any
+// subsequent manipulation, so can be read without locking, but must not be modified by the caller.
+type SyncVoorhees struct {
+	mu sync.RWMutex
+	v  *Voorhees
+
+	// deliveries holds the events of each batch, in the order the batches were applied, until they are delivered by
+	// the single goroutine that is delivering. Both are guarded by mu.
+	deliveries []func()
+	delivering bool
+}
+
+// NewSyncVoorhees creates a new SyncVoorhees instance, accepting the initial map[string]interface{} for manipulation.
+// As with CopyOnWrite, the source document is shared and never modified.
+func NewSyncVoorhees(x map[string]interface{}, opts ...Option) *SyncVoorhees {
+	v := NewVoorhees(x, append(append([]Option{}, opts...), CopyOnWrite())...) // never append to the caller's opts
+	v.detach(v.JSON)
+
+	return &SyncVoorhees{v: v}
+}
+
+// JSON returns the current state of the document.
+func (s *SyncVoorhees) JSON() map[string]interface{} {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.v.JSON
+}
+
+// Get returns the value of the property denoted at the end of the provided JSON path.
+func (s *SyncVoorhees) Get(path string) (interface{}, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.v.get(path)
+}
+
+// Add creates an addition property of the provided value at path, as Voorhees.Add.
+func (s *SyncVoorhees) Add(path string, val interface{}) (map[string]interface{}, error) {
+	return s.Apply(Operation{Op: OpAdd, Path: path, Value: val})
+}
+
+// Change replaces the property denoted at the end of the provided JSON path with the value provided,
+// as Voorhees.Change.
+func (s *SyncVoorhees) Change(path string, val interface{}) (map[string]interface{}, error) {
+	return s.Apply(Operation{Op: OpChange, Path: path, Value: val})
+}
+
+// Delete removes the property denoted at the end of the provided JSON path, as Voorhees.Delete.
+func (s *SyncVoorhees) Delete(path string) (map[string]interface{}, error) {
+	return s.Apply(Operation{Op: OpDelete, Path: path})
+}
+
+// Apply performs each of the provided operations as a single atomic change. If any operation fails, none of them
+// are applied, and readers will only ever observe the document before or after all of them.
+func (s *SyncVoorhees) Apply(ops ...Operation) (map[string]interface{}, error) {
+	return s.write(func() error {
+		return s.v.applyAtomically(ops)
+	})
+}
+
+// write performs fn with exclusive access to the document, then delivers the events of any operations it applied.
+func (s *SyncVoorhees) write(fn func() error) (map[string]interface{}, error) {
+	s.mu.Lock()
+
+	err := fn()
+	s.v.detach(s.v.JSON) // the document is now shared with readers, so must be copied before it is next modified
+	result := s.v.JSON
+
+	s.deliveries = append(s.deliveries, s.v.flush())
+	deliver := !s.delivering
+	s.delivering = true
+
+	s.mu.Unlock()
+	if deliver {
+		s.deliver() // watchers are free to read or manipulate the document
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// deliver delivers queued events until there are none left. Only one goroutine delivers at a time, so events are
+// always delivered in the order their batches were applied, and without holding any lock.
+func (s *SyncVoorhees) deliver() {
+	delivered := false
+	defer func() {
+		if !delivered { // a watcher panicked, so leave the remaining events to the next write
+			s.mu.Lock()
+			s.delivering = false
+			s.mu.Unlock()
+		}
+	}()
+
+	for {
+		s.mu.Lock()
+		if len(s.deliveries) == 0 {
+			s.delivering = false
+			s.mu.Unlock()
+
+			delivered = true
+			return
+		}
+
+		next := s.deliveries[0]
+		s.deliveries = s.deliveries[1:]
+		s.mu.Unlock()
+
+		next()
+	}
+}
+
+// Watch calls fn with an Event for every successful operation that affects the nodes matched by pattern, as
+// Voorhees.Watch. Events are delivered once each batch has been applied, in the order the batches were applied,
+// and without holding any lock, so fn may itself use the SyncVoorhees. Events are delivered by whichever write
+// finds no other write delivering, so a write may return before its own events have been delivered, i.e when
+// made by fn, in which case they are delivered once fn returns.
+func (s *SyncVoorhees) Watch(pattern string, fn func(Event)) (func(), error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cancel, err := s.v.Watch(pattern, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		cancel()
+	}, nil
+}
@@ -0,0 +1,109 @@
+package voorhees
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncVoorheesConcurrentReadsAndWrites(t *testing.T) {
+	input := copyInput(syncInput)
+	s := NewSyncVoorhees(input)
+
+	const writes = 500
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(done)
+		for i := 1; i <= writes; i++ {
+			_, err := s.Apply(
+				Operation{OpChange, "counters.a", float64(i)},
+				Operation{OpAdd, indexPath("history", i-1), float64(i)},
+				Operation{OpChange, "counters.b", float64(i)},
+			)
+			assert.NoError(t, err)
+		}
+	}()
+
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				doc := s.JSON()
+				v := &Voorhees{JSON: doc}
+				v.Walk(func(path string, value interface{}) error { return nil }) // reads every node
+
+				counters := doc["counters"].(map[string]interface{})
+				history := doc["history"].([]interface{})
+				if !assert.Equal(t, counters["a"], counters["b"], "Expected to never observe a half-applied change") {
+					return
+				}
+				assert.Equal(t, counters["a"], float64(len(history)))
+
+				a, err := s.Get("counters.a")
+				assert.NoError(t, err)
+				assert.True(t, a.(float64) >= counters["a"].(float64), "Expected counters to only increase")
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, syncInput, input, "Expected the source document to never be modified")
+	assert.Equal(t, float64(writes), s.JSON()["counters"].(map[string]interface{})["b"])
+}
+
+func TestSyncVoorheesResultsAreStable(t *testing.T) {
+	s := NewSyncVoorhees(copyInput(syncInput))
+
+	before, err := s.Change("counters.a", 1.0)
+	assert.NoError(t, err)
+
+	s.Change("counters.a", 2.0)
+	s.Delete("static")
+
+	assert.Equal(t, 1.0, before["counters"].(map[string]interface{})["a"])
+	assert.Contains(t, before, "static")
+}
+
+func TestSyncVoorheesApplyIsAtomic(t *testing.T) {
+	s := NewSyncVoorhees(copyInput(syncInput), RecordHistory())
+
+	_, err := s.Apply(
+		Operation{OpChange, "counters.a", 1.0},
+		Operation{OpAdd, "created.deeply.nested", true},
+		Operation{OpAdd, "history[2]", "grown"},
+		Operation{Op: OpDelete, Path: "static.untouched[0]"},
+		Operation{OpChange, "counters.doesNotExist", 1.0},
+	)
+
+	assert.EqualError(t, err, "[Voorhees]: Unable to change doesNotExist because it doesn't exist at path counters")
+	assert.Equal(t, syncInput, s.JSON(), "Expected a failing batch to leave the document untouched")
+
+	_, err = s.Apply(Operation{OpChange, "counters.a", 1.0}, Operation{OpChange, "counters.b", 1.0})
+	assert.NoError(t, err)
+
+	result, err := s.v.Undo()
+	assert.NoError(t, err)
+	assert.Equal(t, syncInput, result, "Expected a batch to be undone as a single step")
+}
+
+func TestNewSyncVoorheesDoesNotModifyOptions(t *testing.T) {
+	opts := make([]Option, 1, 2)
+	opts[0] = RecordHistory()
+
+	NewSyncVoorhees(copyInput(syncInput), opts...)
+
+	assert.Nil(t, opts[:2][1], "Expected NewSyncVoorhees to leave the spare capacity of opts untouched")
+}
@@ -0,0 +1,187 @@
+package voorhees
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Transform derives a new value from the value it replaces, for use with Update.
+type Transform func(old interface{}) (interface{}, error)
+
+// Update replaces the property denoted at the end of the provided JSON path with the value derived from it by fn.
+// The path may contain wildcards, i.e items[*].price, in which case every matching node is updated, and nodes that
+// don't match are left alone. Either every node is updated, or if fn fails for any of them, none are.
+func (v *Voorhees) Update(path string, fn Transform) (map[string]interface{}, error) {
+	defer v.notify()
+
+	ops, err := v.updates(path, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.applyAtomically(ops); err != nil {
+		return nil, err
+	}
+
+	return v.result(v.JSON, nil)
+}
+
+// Update replaces the property denoted at the end of the provided JSON path with the value derived from it by fn,
+// as Voorhees.Update. The value is read and replaced atomically, so concurrent updates never overwrite each other.
+// fn is called with exclusive access to the document, so it must not use the SyncVoorhees itself.
+func (s *SyncVoorhees) Update(path string, fn Transform) (map[string]interface{}, error) {
+	return s.write(func() error {
+		ops, err := s.v.updates(path, fn)
+		if err != nil {
+			return err
+		}
+
+		return s.v.applyAtomically(ops)
+	})
+}
+
+// updates returns the Change operations that apply fn to every node matched by path.
+func (v *Voorhees) updates(path string, fn Transform) ([]Operation, error) {
+	pattern, err := compilePattern(path)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{path}
+	if pattern.hasWildcard() {
+		paths = nil
+		for _, p := range v.Paths(PathOptions{MaxDepth: len(pattern)}) {
+			if pattern.matches(p) {
+				paths = append(paths, p)
+			}
+		}
+	}
+
+	ops := make([]Operation, len(paths))
+	for i, p := range paths {
+		old, err := v.get(p)
+		if err != nil {
+			return nil, fmt.Errorf("[Voorhees]: Unable to update %s. %s", p, err)
+		}
+
+		val, err := fn(old)
+		if err != nil {
+			return nil, fmt.Errorf("[Voorhees]: Unable to update %s. %s", p, err)
+		}
+
+		ops[i] = Operation{Op: OpChange, Path: p, Value: val}
+	}
+
+	return ops, nil
+}
+
+// Increment adds by to a number. A json.Number remains a json.Number, exact whenever the result is a whole number, and
+// an integer keeps its type whenever the result is a whole number within its range. Any other result is a float64,
+// which is only exact up to 2^53.
+func Increment(by float64) Transform {
+	return func(old interface{}) (interface{}, error) {
+		n, isNumber := asNumber(old)
+		if !isNumber {
+			return nil, fmt.Errorf("Increment requires a number, not %s", KindOf(old))
+		}
+
+		if math.IsNaN(by) || math.IsInf(by, 0) {
+			f, _ := n.Float64()
+			return f + by, nil
+		}
+
+		sum := new(big.Rat).Add(n, new(big.Rat).SetFloat64(by))
+		f, _ := sum.Float64()
+
+		if _, isNumber := old.(json.Number); isNumber {
+			if sum.IsInt() {
+				return json.Number(sum.Num().String()), nil
+			}
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), nil
+		}
+
+		if sum.IsInt() {
+			if i, fits := sameIntType(old, sum.Num()); fits {
+				return i, nil
+			}
+		}
+
+		return f, nil
+	}
+}
+
+// sameIntType converts i to the integer type of x, reporting false if x is not an integer or i is out of its range.
+func sameIntType(x interface{}, i *big.Int) (interface{}, bool) {
+	val := reflect.ValueOf(x)
+	converted := reflect.New(val.Type()).Elem()
+
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if !i.IsInt64() || converted.OverflowInt(i.Int64()) {
+			return nil, false
+		}
+		converted.SetInt(i.Int64())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if !i.IsUint64() || converted.OverflowUint(i.Uint64()) {
+			return nil, false
+		}
+		converted.SetUint(i.Uint64())
+	default:
+		return nil, false
+	}
+
+	return converted.Interface(), true
+}
+
+// Append adds vals to the end of an array.
+func Append(vals ...interface{}) Transform {
+	return func(old interface{}) (interface{}, error) {
+		a, isArray := asArray(old)
+		if !isArray {
+			return nil, fmt.Errorf("Append requires an array, not %s", KindOf(old))
+		}
+
+		return append(append([]interface{}{}, a...), vals...), nil
+	}
+}
+
+// Prepend adds vals to the start of an array.
+func Prepend(vals ...interface{}) Transform {
+	return func(old interface{}) (interface{}, error) {
+		a, isArray := asArray(old)
+		if !isArray {
+			return nil, fmt.Errorf("Prepend requires an array, not %s", KindOf(old))
+		}
+
+		return append(append([]interface{}{}, vals...), a...), nil
+	}
+}
+
+// Toggle negates a boolean.
+func Toggle() Transform {
+	return func(old interface{}) (interface{}, error) {
+		b, isBool := old.(bool)
+		if !isBool {
+			return nil, fmt.Errorf("Toggle requires a boolean, not %s", KindOf(old))
+		}
+
+		return !b, nil
+	}
+}
+
+// StringReplace replaces every occurrence of old within a string with new.
+func StringReplace(old, new string) Transform {
+	return func(val interface{}) (interface{}, error) {
+		s, isString := val.(string)
+		if !isString {
+			return nil, fmt.Errorf("StringReplace requires a string, not %s", KindOf(val))
+		}
+
+		return strings.Replace(s, old, new, -1), nil
+	}
+}
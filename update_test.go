@@ -0,0 +1,159 @@
+package voorhees
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdate(t *testing.T) {
+	type testCase struct {
+		path     string
+		fn       Transform
+		expected map[string]interface{}
+	}
+
+	testCases := []testCase{
+		testCase{"count", Increment(2), map[string]interface{}{"count": 3.0}},
+		testCase{"count", Increment(-1.5), map[string]interface{}{"count": -0.5}},
+		testCase{"enabled", Toggle(), map[string]interface{}{"enabled": false}},
+		testCase{"name", StringReplace("a", "4"), map[string]interface{}{"name": "c4mp cryst4l l4ke"}},
+		testCase{"tags", Append("c", "d"), map[string]interface{}{"tags": []interface{}{"b", "c", "d"}}},
+		testCase{"tags", Prepend("a"), map[string]interface{}{"tags": []interface{}{"a", "b"}}},
+		testCase{"name", func(old interface{}) (interface{}, error) {
+			return strings.ToUpper(old.(string)), nil
+		}, map[string]interface{}{"name": "CAMP CRYSTAL LAKE"}},
+		testCase{"items[*].price", func(old interface{}) (interface{}, error) {
+			return old.(float64) * 2, nil
+		}, map[string]interface{}{"items": []interface{}{
+			map[string]interface{}{"price": 20.0, "name": "axe"},
+			map[string]interface{}{"price": 5.0, "name": "mask"},
+			map[string]interface{}{"name": "priceless"},
+		}}},
+		testCase{"matrix[*][0]", Increment(10), map[string]interface{}{"matrix": []interface{}{
+			[]interface{}{11.0, 2.0},
+			[]interface{}{13.0},
+		}}},
+		testCase{"missing[*].price", Increment(1), map[string]interface{}{}},
+	}
+
+	for _, testCase := range testCases {
+		expected := copyInput(updateInput)
+		for k, val := range testCase.expected {
+			expected[k] = val
+		}
+
+		result, err := NewVoorhees(updateInput).Update(testCase.path, testCase.fn)
+
+		assert.NoError(t, err, testCase.path)
+		assert.Equal(t, expected, result, "Unexpected result of updating %s", testCase.path)
+	}
+}
+
+func TestIncrementKeepsPrecision(t *testing.T) {
+	type testCase struct {
+		old      interface{}
+		by       float64
+		expected interface{}
+	}
+
+	testCases := []testCase{
+		testCase{json.Number("12345678901234567890"), 1, json.Number("12345678901234567891")},
+		testCase{json.Number("-1"), 1, json.Number("0")},
+		testCase{json.Number("1.5"), 1, json.Number("2.5")},
+		testCase{json.Number("1"), 0.25, json.Number("1.25")},
+		testCase{int64(1) << 60, 1, int64(1)<<60 + 1},
+		testCase{int8(1), 2, int8(3)},
+		testCase{uint(1), -1, uint(0)},
+		testCase{int8(127), 1, 128.0},
+		testCase{uint(0), -1, -1.0},
+		testCase{1, 0.5, 1.5},
+		testCase{float32(1), 1, 2.0},
+	}
+
+	for _, testCase := range testCases {
+		result, err := Increment(testCase.by)(testCase.old)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, result, "Unexpected result of incrementing %T(%v) by %v",
+			testCase.old, testCase.old, testCase.by)
+	}
+}
+
+func TestUpdateErrors(t *testing.T) {
+	type testCase struct {
+		path     string
+		fn       Transform
+		expected string
+	}
+
+	testCases := []testCase{
+		testCase{"name", Increment(1), "[Voorhees]: Unable to update name. Increment requires a number, not string"},
+		testCase{"count", Append(1), "[Voorhees]: Unable to update count. Append requires an array, not number"},
+		testCase{"count", Prepend(1), "[Voorhees]: Unable to update count. Prepend requires an array, not number"},
+		testCase{"count", Toggle(), "[Voorhees]: Unable to update count. Toggle requires a boolean, not number"},
+		testCase{"tags", StringReplace("a", "b"), "[Voorhees]: Unable to update tags. StringReplace requires a string, not array"},
+		testCase{"missing", Toggle(),
+			"[Voorhees]: Unable to update missing. [Voorhees]: Unable to get missing because it doesn't exist at path missing"},
+		testCase{"items[*].name", StringReplace("a", "b"), ""},
+		testCase{"items[*].name", func(old interface{}) (interface{}, error) {
+			if old == "mask" {
+				return nil, errors.New("masks are not for sale")
+			}
+			return "sold", nil
+		}, "[Voorhees]: Unable to update items[1].name. masks are not for sale"},
+		testCase{"items[x]", Toggle(), "[Voorhees]: Array Path | items[x] is not a valid array denotion"},
+	}
+
+	for _, testCase := range testCases {
+		v := NewVoorhees(updateInput)
+		_, err := v.Update(testCase.path, testCase.fn)
+
+		if testCase.expected == "" {
+			assert.NoError(t, err)
+			continue
+		}
+
+		assert.EqualError(t, err, testCase.expected)
+		assert.Equal(t, updateInput, v.JSON, "Expected a failed update of %s to leave the document untouched",
+			testCase.path)
+	}
+}
+
+func TestUpdateIsASingleStep(t *testing.T) {
+	v := NewVoorhees(updateInput, RecordHistory())
+
+	var events []Event
+	v.Watch("items", func(e Event) { events = append(events, e) })
+
+	v.Update("items[*].price", Increment(1))
+	assert.Len(t, events, 2)
+
+	result, err := v.Undo()
+	assert.NoError(t, err)
+	assert.Equal(t, updateInput, result)
+}
+
+func TestSyncVoorheesUpdate(t *testing.T) {
+	s := NewSyncVoorhees(copyInput(updateInput))
+
+	var wg sync.WaitGroup
+	for w := 0; w < 8; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				_, err := s.Update("count", Increment(1))
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	count, _ := s.Get("count")
+	assert.Equal(t, 401.0, count, "Expected concurrent increments to never overwrite each other")
+}
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -13,19 +12,101 @@ import (
 // Voorhees allows json path denoted manipulations of a map[string]interface{}
 type Voorhees struct {
 	JSON map[string]interface{}
+
+	cow     bool
+	owned   map[uintptr]interface{} // the nodes that are safe to modify in CopyOnWrite mode, keyed by nodePointer
+	sweepAt int                     // the number of owned nodes at which those no longer in the document are forgotten
+
+	history     *history
+	snapshots   []map[string]interface{}
+	independent bool
+	schema      *Schema
+
+	watchers []*watcher
+	pending  []Event // events waiting to be delivered to watchers
 }
 
 // NewVoorhees creates a new Voorhees instance, accepting the initial map[string]interface{} for manipulation
 // This preliminary value is deep copied, so any subsequence modificiations do not effect the original struct.
-func NewVoorhees(x map[string]interface{}) *Voorhees {
-	json := deepCopy(x)
+// Any opts provided are applied to the new instance, i.e NewVoorhees(x, CopyOnWrite()).
+func NewVoorhees(x map[string]interface{}, opts ...Option) *Voorhees {
+	v := &Voorhees{}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	if v.cow {
+		v.JSON = v.prepare(x, true).(map[string]interface{})
+		return v
+	}
+
+	v.JSON = deepCopy(x)
+	return v
+}
+
+// Get returns the value of the property denoted at the end of the provided JSON path.
+func (v *Voorhees) Get(path string) (interface{}, error) {
+	val, err := v.get(path)
+	if err != nil || !v.independent {
+		return val, err
+	}
+
+	return v.detach(val), nil
+}
+
+func (v *Voorhees) get(path string) (interface{}, error) {
+	toGet := finalPropertyOfPath(path)
+	level := &v.JSON
+
+	if toGet != path {
+		path = trimLastPropertyFromPath(path)
+		var err error
+		level, err = v.navigateToPath(path, "get")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var val interface{}
+	exists := true
+
+	if denotesArray(toGet) {
+		err := v.modifyArrayElement(level, toGet, "get", func(a []interface{}, i int) ([]interface{}, error) {
+			val = a[i]
+			return a, nil
+		})
+		exists = err == nil
+	} else {
+		val, exists = (*level)[unescapeProperty(toGet)]
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("[Voorhees]: Unable to get %s because it doesn't exist at path %s", toGet, path)
+	}
 
-	return &Voorhees{json}
+	return val, nil
 }
 
 // Add creates an addition property of the provided value at path.
 // Add will also create any nodes that are not present in the path during traversal.
 func (v *Voorhees) Add(path string, val interface{}) (map[string]interface{}, error) {
+	defer v.notify()
+	return v.result(v.mutate(Operation{Op: OpAdd, Path: path, Value: val}))
+}
+
+// Change replaces the property denoted at the end of the provided JSON path with the value provided.
+func (v *Voorhees) Change(path string, val interface{}) (map[string]interface{}, error) {
+	defer v.notify()
+	return v.result(v.mutate(Operation{Op: OpChange, Path: path, Value: val}))
+}
+
+// Delete removes the property denoted at the end of the provided JSON path.
+func (v *Voorhees) Delete(path string) (map[string]interface{}, error) {
+	defer v.notify()
+	return v.result(v.mutate(Operation{Op: OpDelete, Path: path}))
+}
+
+func (v *Voorhees) add(path string, val interface{}) (map[string]interface{}, error) {
 	toAdd := finalPropertyOfPath(path)
 	level := &v.JSON
 
@@ -38,15 +119,26 @@ func (v *Voorhees) Add(path string, val interface{}) (map[string]interface{}, er
 		}
 	}
 
+	if denotesArray(toAdd) {
+		err := v.modifyArrayElement(level, toAdd, "add", func(a []interface{}, i int) ([]interface{}, error) {
+			a[i] = val
+			return a, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[Voorhees]: Unable to add %s at path %s. %s", toAdd, path, err)
+		}
+
+		return v.JSON, nil
+	}
+
 	intermediatry := *level
-	intermediatry[toAdd] = val
+	intermediatry[unescapeProperty(toAdd)] = val
 	level = &intermediatry
 
 	return v.JSON, nil
 }
 
-// Change replaces the property denoted at the end of the provided JSON path with the value provided.
-func (v *Voorhees) Change(path string, val interface{}) (map[string]interface{}, error) {
+func (v *Voorhees) change(path string, val interface{}) (map[string]interface{}, error) {
 	toChange := finalPropertyOfPath(path)
 	level := &v.JSON
 
@@ -59,21 +151,33 @@ func (v *Voorhees) Change(path string, val interface{}) (map[string]interface{},
 		}
 	}
 
+	if denotesArray(toChange) {
+		err := v.modifyArrayElement(level, toChange, "change", func(a []interface{}, i int) ([]interface{}, error) {
+			a[i] = val
+			return a, nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[Voorhees]: Unable to change %s because it doesn't exist at path %s",
+				toChange, path)
+		}
+
+		return v.JSON, nil
+	}
+
 	intermediatry := *level
 
-	if _, exists := intermediatry[toChange]; !exists {
+	if _, exists := intermediatry[unescapeProperty(toChange)]; !exists {
 		return nil, fmt.Errorf("[Voorhees]: Unable to change %s because it doesn't exist at path %s",
 			toChange, path)
 	}
 
-	intermediatry[toChange] = val
+	intermediatry[unescapeProperty(toChange)] = val
 	level = &intermediatry
 
 	return v.JSON, nil
 }
 
-// Delete removes the property denoted at the end of the provided JSON path.
-func (v *Voorhees) Delete(path string) (map[string]interface{}, error) {
+func (v *Voorhees) remove(path string) (map[string]interface{}, error) {
 	toDelete := finalPropertyOfPath(path)
 	level := &v.JSON
 
@@ -86,7 +190,19 @@ func (v *Voorhees) Delete(path string) (map[string]interface{}, error) {
 		}
 	}
 
-	delete(*level, toDelete)
+	if denotesArray(toDelete) {
+		err := v.modifyArrayElement(level, toDelete, "delete", func(a []interface{}, i int) ([]interface{}, error) {
+			return append(a[:i], a[i+1:]...), nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("[Voorhees]: Unable to delete %s because it doesn't exist at path %s",
+				toDelete, path)
+		}
+
+		return v.JSON, nil
+	}
+
+	delete(*level, unescapeProperty(toDelete))
 
 	return v.JSON, nil
 }
@@ -120,14 +236,14 @@ func (v *Voorhees) navigateToPath(path, parentOp string) (level *map[string]inte
 		return &v.JSON, nil
 	}
 
-	nestedProperties := strings.Split(path, ".")
+	nestedProperties := splitPath(path)
 
 	level = &v.JSON
 	for _, prop := range nestedProperties {
 		propThatPanicked = prop
 
 		if denotesArray(prop) {
-			level, err = navigateIntoArray(level, prop, parentOp)
+			level, err = v.navigateIntoArray(level, prop, parentOp)
 			if err != nil {
 				return nil, fmt.Errorf("[Voorhees]: Unable to navigate to %s. Failed to find node: %s", path, prop)
 			}
@@ -135,58 +251,130 @@ func (v *Voorhees) navigateToPath(path, parentOp string) (level *map[string]inte
 		}
 
 		l := *level
-		_, exists := l[prop]
+		key := unescapeProperty(prop)
+		_, exists := l[key]
 
 		if !exists {
 			if parentOp == "add" { // during an add, we create any nonexistant nodes in the path
-				l[prop] = make(map[string]interface{})
+				l[key] = make(map[string]interface{})
+				v.own(l[key])
 			} else {
 				return nil, fmt.Errorf("[Voorhees]: Unable to navigate to %s. Failed to find node: %s", path, prop)
 			}
 		}
 
-		intermediary := l[prop].(map[string]interface{}) // potential panic
+		next := v.prepare(l[key], parentOp != "get")
+		if parentOp != "get" {
+			l[key] = next
+		}
+
+		intermediary := next.(map[string]interface{}) // potential panic
 		level = &intermediary
 	}
 
 	return level, nil
 }
 
-func navigateIntoArray(level *map[string]interface{}, prop, op string) (*map[string]interface{}, error) {
-	var arrayIndex int
-	prop, arrayIndex, err := deconstructArrayPath(prop)
+func (v *Voorhees) navigateIntoArray(level *map[string]interface{}, prop, op string) (*map[string]interface{},
+	error) {
+
+	var l map[string]interface{}
+
+	err := v.modifyArrayElement(level, prop, op, func(a []interface{}, i int) ([]interface{}, error) {
+		if a[i] == nil && op == "add" { // during an add, we create any nonexistant nodes in the path
+			a[i] = map[string]interface{}{}
+			v.own(a[i])
+		}
+
+		next := v.prepare(a[i], op != "get")
+		if op != "get" {
+			a[i] = next
+		}
+
+		l = next.(map[string]interface{}) // potential panic
+		return a, nil
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
+	return &l, nil
+}
+
+// modifyArrayElement resolves an array denotion such as matrix[1][2] against level, handing the innermost array
+// and index to fn. Whatever array fn returns is written back into level, so arrays can be grown or shrunk.
+func (v *Voorhees) modifyArrayElement(level *map[string]interface{}, prop, op string,
+	fn func(a []interface{}, i int) ([]interface{}, error)) error {
+
+	prop, arrayIndexes, err := deconstructArrayPath(prop)
+
+	if err != nil {
+		return err
+	}
+
 	l := *level
 	val, exists := l[prop]
 
-	if !exists {
-		if op == "add" { // during an add, we create any nonexistant nodes in the path
-			val = make([]interface{}, arrayIndex+1)
-			intermediary := val.([]interface{})
-			intermediary[arrayIndex] = map[string]interface{}{}
-			val = intermediary
-			l[prop] = val // we must add our newly created array and item at arrayIndex to the source
-		} else {
-			return nil, fmt.Errorf("[Voorhees]: Unable to find array: %s", prop)
+	if !exists && op != "add" {
+		return fmt.Errorf("[Voorhees]: Unable to find array: %s", prop)
+	}
+
+	a, err := v.modifyArray(val, arrayIndexes, op == "add", op != "get", fn)
+	if err != nil {
+		return err
+	}
+
+	if op != "get" {
+		l[prop] = a // we must write our array back to the source in case it was created, copied or resized
+	}
+	return nil
+}
+
+// modifyArray follows arrayIndexes through the (potentially nested) arrays held in val, handing the innermost
+// array and index to fn. When create is set, missing arrays are created and short arrays are padded with nil.
+// When mutating, the arrays are prepared to be modified, and must be written back by the caller.
+func (v *Voorhees) modifyArray(val interface{}, arrayIndexes []int, create, mutating bool,
+	fn func(a []interface{}, i int) ([]interface{}, error)) ([]interface{}, error) {
+
+	a, isArray := v.prepare(val, mutating).([]interface{})
+
+	if !isArray && !(create && val == nil) {
+		return nil, fmt.Errorf("[Voorhees]: Expected an array but found %T", val)
+	}
+
+	arrayIndex := arrayIndexes[0]
+
+	if arrayIndex >= len(a) {
+		if !create {
+			return nil, fmt.Errorf("[Voorhees]: Index %d is out of range for array of length %d", arrayIndex, len(a))
 		}
+		a = append(a, make([]interface{}, arrayIndex+1-len(a))...)
+		v.own(a)
 	}
 
-	a := val.([]interface{})
-	l = a[arrayIndex].(map[string]interface{})
-	return &l, nil
+	if len(arrayIndexes) == 1 {
+		return fn(a, arrayIndex)
+	}
+
+	inner, err := v.modifyArray(a[arrayIndex], arrayIndexes[1:], create, mutating, fn)
+	if err != nil {
+		return nil, err
+	}
+
+	if mutating {
+		a[arrayIndex] = inner
+	}
+	return a, nil
 }
 
 func finalPropertyOfPath(path string) string {
-	split := strings.Split(path, ".")
+	split := splitPath(path)
 	return split[len(split)-1]
 }
 
 func trimLastPropertyFromPath(path string) string {
-	split := strings.Split(path, ".")
+	split := splitPath(path)
 
 	if len(split) == 1 {
 		return path
@@ -196,23 +384,114 @@ func trimLastPropertyFromPath(path string) string {
 }
 
 func denotesArray(path string) bool {
-	return strings.Contains(path, "[") && strings.Contains(path, "]")
+	return indexUnescaped(path, '[') != -1 && indexUnescaped(path, ']') != -1
+}
+
+// splitPath splits path into its properties, ignoring any dots that have been escaped with a backslash.
+func splitPath(path string) []string {
+	var split []string
+	start := 0
+
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '\\':
+			i++ // skip whatever has been escaped
+		case '.':
+			split = append(split, path[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(split, path[start:])
+}
+
+// indexUnescaped returns the index of the first occurrence of c in s that has not been escaped with a backslash,
+// or -1 if there is none.
+func indexUnescaped(s string, c byte) int {
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case c:
+			return i
+		}
+	}
+
+	return -1
 }
 
-func deconstructArrayPath(s string) (string, int, error) {
-	openingBraceIndex := strings.Index(s, "[")
-	re := regexp.MustCompile("[0-9]+")
-	result := re.FindAllString(s, 1)
+// emptyProperty is the escaped form of the empty map key, which would otherwise denote the root of the document.
+const emptyProperty = `\"\"`
 
-	if len(result) == 0 {
-		return "", 0, fmt.Errorf("[Voorhees]: Array Path | %s is not a valid array denotion", s)
+// escapeProperty escapes any characters in a map key that would otherwise be interpreted as path syntax, so that
+// keys such as "a.b" or "x[0]" can still be addressed, i.e. a\\.b and x\\[0\\]. The empty key is written as \"\".
+func escapeProperty(prop string) string {
+	if prop == "" {
+		return emptyProperty
 	}
 
-	arrayIndex, err := strconv.Atoi(result[0])
+	var b strings.Builder
 
-	if err != nil {
-		return "", 0, fmt.Errorf("[Voorhees]: Array Path | %s is not a valid array denotion", s)
+	for i := 0; i < len(prop); i++ {
+		switch prop[i] {
+		case '\\', '.', '[', ']':
+			b.WriteByte('\\')
+		}
+		b.WriteByte(prop[i])
+	}
+
+	return b.String()
+}
+
+// unescapeProperty reverses escapeProperty, returning the map key a property of a path refers to.
+func unescapeProperty(prop string) string {
+	if prop == emptyProperty {
+		return ""
+	}
+
+	if !strings.Contains(prop, "\\") {
+		return prop
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(prop); i++ {
+		if prop[i] == '\\' && i+1 < len(prop) {
+			i++
+		}
+		b.WriteByte(prop[i])
+	}
+
+	return b.String()
+}
+
+// deconstructArrayPath splits an array denotion into the name of the array and the chain of indexes that follow
+// it, i.e. matrix[1][2] becomes "matrix" and [1, 2].
+func deconstructArrayPath(s string) (string, []int, error) {
+	invalid := fmt.Errorf("[Voorhees]: Array Path | %s is not a valid array denotion", s)
+	openingBraceIndex := indexUnescaped(s, '[')
+
+	if openingBraceIndex == -1 {
+		return "", nil, invalid
+	}
+
+	var arrayIndexes []int
+	for rest := s[openingBraceIndex:]; rest != ""; {
+		closingBraceIndex := strings.Index(rest, "]")
+
+		if rest[0] != '[' || closingBraceIndex == -1 {
+			return "", nil, invalid
+		}
+
+		arrayIndex, err := strconv.Atoi(rest[1:closingBraceIndex])
+
+		if err != nil || arrayIndex < 0 {
+			return "", nil, invalid
+		}
+
+		arrayIndexes = append(arrayIndexes, arrayIndex)
+		rest = rest[closingBraceIndex+1:]
 	}
 
-	return s[0:openingBraceIndex], arrayIndex, nil
+	return unescapeProperty(s[0:openingBraceIndex]), arrayIndexes, nil
 }
@@ -572,19 +572,21 @@ func TestTrimLastPropertyFromPath(t *testing.T) {
 
 func TestDeconstructArrayPath(t *testing.T) {
 	type testCase struct {
-		s             string
-		expectedName  string
-		expectedIndex int
+		s               string
+		expectedName    string
+		expectedIndexes []int
 	}
 
 	testCases := []testCase{
-		testCase{"array[0]", "array", 0},
-		testCase{"array[4]", "array", 4},
-		testCase{"array[14]", "array", 14},
+		testCase{"array[0]", "array", []int{0}},
+		testCase{"array[4]", "array", []int{4}},
+		testCase{"array[14]", "array", []int{14}},
+		testCase{"matrix[1][2]", "matrix", []int{1, 2}},
+		testCase{"cube[0][10][3]", "cube", []int{0, 10, 3}},
 	}
 
 	for _, testCase := range testCases {
-		name, index, err := deconstructArrayPath(testCase.s)
+		name, indexes, err := deconstructArrayPath(testCase.s)
 
 		assert.NoError(t, err)
 
@@ -593,9 +595,9 @@ func TestDeconstructArrayPath(t *testing.T) {
 				testCase.s, testCase.expectedName, name)
 		}
 
-		if index != testCase.expectedIndex {
-			t.Errorf("Expected deconstructArrayPath(\"%s\") to return index \"%d\". Got: %d",
-				testCase.s, testCase.expectedIndex, index)
+		if !reflect.DeepEqual(indexes, testCase.expectedIndexes) {
+			t.Errorf("Expected deconstructArrayPath(\"%s\") to return indexes \"%v\". Got: %v",
+				testCase.s, testCase.expectedIndexes, indexes)
 		}
 	}
 }
@@ -610,3 +612,151 @@ func TestDeconstructArrayPathPanic(t *testing.T) {
 
 	deconstructArrayPath("array[not good]")
 }
+
+func TestDeconstructInvalidArrayPath(t *testing.T) {
+	invalid := []string{"array[not good]", "array[1", "array[1]x", "array[1][", "array[-1]"}
+
+	for _, s := range invalid {
+		_, _, err := deconstructArrayPath(s)
+
+		assert.Error(t, err, "Expected deconstructArrayPath(\"%s\") to fail", s)
+	}
+}
+
+func TestGet(t *testing.T) {
+	type testCase struct {
+		path     string
+		expected interface{}
+	}
+
+	input := map[string]interface{}{
+		"keepMe": "please",
+		"layer1": map[string]interface{}{
+			"array": []interface{}{
+				map[string]interface{}{
+					"getMe": "excellent",
+				},
+			},
+		},
+		"matrix": []interface{}{
+			[]interface{}{"a", "b"},
+			[]interface{}{
+				"c",
+				map[string]interface{}{
+					"getMe": "excellent",
+				},
+			},
+		},
+	}
+
+	testCases := []testCase{
+		testCase{"keepMe", "please"},
+		testCase{"layer1.array[0].getMe", "excellent"},
+		testCase{"matrix[0][1]", "b"},
+		testCase{"matrix[1][1].getMe", "excellent"},
+		testCase{"matrix[0]", []interface{}{"a", "b"}},
+	}
+
+	for _, testCase := range testCases {
+		result, err := NewVoorhees(input).Get(testCase.path)
+
+		assert.NoError(t, err)
+		assert.Equal(t, testCase.expected, result, "Expected .Get(%s) to return %v", testCase.path, testCase.expected)
+	}
+}
+
+func TestGetNonexistantProperty(t *testing.T) {
+	expected := "[Voorhees]: Unable to get uhoh because it doesn't exist at path layer1"
+
+	testCase := map[string]interface{}{
+		"layer1": map[string]interface{}{},
+	}
+
+	result, err := NewVoorhees(testCase).Get("layer1.uhoh")
+
+	assert.Nil(t, result)
+	assert.Error(t, err)
+	assert.Equal(t, expected, err.Error())
+}
+
+func TestMultiDimensionalArrays(t *testing.T) {
+	input := map[string]interface{}{
+		"matrix": []interface{}{
+			[]interface{}{"a", "b"},
+			[]interface{}{
+				map[string]interface{}{
+					"value": "c",
+				},
+			},
+		},
+	}
+
+	added, err := NewVoorhees(input).Add("matrix[1][0].added", "excellent")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"matrix": []interface{}{
+			[]interface{}{"a", "b"},
+			[]interface{}{
+				map[string]interface{}{
+					"value": "c",
+					"added": "excellent",
+				},
+			},
+		},
+	}, added)
+
+	changed, err := NewVoorhees(input).Change("matrix[0][1]", "changed")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "changed"}, changed["matrix"].([]interface{})[0])
+
+	deleted, err := NewVoorhees(input).Delete("matrix[0][0]")
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"b"}, deleted["matrix"].([]interface{})[0])
+
+	created, err := NewVoorhees(map[string]interface{}{}).Add("grid[1][2].value", "excellent")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"grid": []interface{}{
+			nil,
+			[]interface{}{
+				nil,
+				nil,
+				map[string]interface{}{
+					"value": "excellent",
+				},
+			},
+		},
+	}, created)
+
+	_, err = NewVoorhees(input).Change("matrix[0][5]", "uhoh")
+	assert.Equal(t, "[Voorhees]: Unable to change matrix[0][5] because it doesn't exist at path matrix[0][5]",
+		err.Error())
+
+	_, err = NewVoorhees(input).Change("matrix[1][0].value.uhoh", "uhoh")
+	assert.Equal(t, "[Voorhees]: Unable to navigate to matrix[1][0].value. Node: value was not a map[string]interface{}",
+		err.Error())
+}
+
+func TestEscapedPaths(t *testing.T) {
+	input := map[string]interface{}{
+		"a.b": map[string]interface{}{
+			"c[0]": "d",
+		},
+	}
+
+	result, err := NewVoorhees(input).Change(`a\.b.c\[0\]`, "changed")
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"a.b": map[string]interface{}{
+			"c[0]": "changed",
+		},
+	}, result)
+
+	assert.Equal(t, `c\[0\]`, finalPropertyOfPath(`a\.b.c\[0\]`))
+	assert.Equal(t, `a\.b`, trimLastPropertyFromPath(`a\.b.c\[0\]`))
+	assert.Equal(t, `back\\slash\.`, escapeProperty(`back\slash.`))
+	assert.Equal(t, `back\slash.`, unescapeProperty(`back\\slash\.`))
+	assert.Equal(t, `\"\"`, escapeProperty(""))
+	assert.Equal(t, "", unescapeProperty(`\"\"`))
+}
@@ -0,0 +1,96 @@
+package voorheestest
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sHesl/voorhees"
+)
+
+// AssertPathEquals asserts that the value at path inside doc is equal to expected, reporting any nodes beneath
+// path that differ. Numbers are compared by value, regardless of their type.
+func AssertPathEquals(t testing.TB, doc map[string]interface{}, path string, expected interface{}) bool {
+	t.Helper()
+
+	actual, err := voorhees.NewVoorhees(doc).Get(path)
+	if err != nil {
+		t.Errorf("voorheestest: %s, because %s", voorhees.Difference{Path: path, A: expected, MissingFromB: true}, err)
+		return false
+	}
+
+	diffs := voorhees.Diff(expected, actual)
+	for i := range diffs {
+		diffs[i].Path = appendPath(path, diffs[i].Path)
+	}
+
+	return reportDiffs(t, diffs)
+}
+
+// AssertPathMissing asserts that nothing exists at path inside doc.
+func AssertPathMissing(t testing.TB, doc map[string]interface{}, path string) bool {
+	t.Helper()
+
+	actual, err := voorhees.NewVoorhees(doc).Get(path)
+	if err == nil {
+		t.Errorf("voorheestest: %s, because %s should be missing",
+			voorhees.Difference{Path: path, B: actual, MissingFromA: true}, path)
+		return false
+	}
+
+	return true
+}
+
+// AssertSubset asserts that every node in subset is present, and equal, in doc. Any additional nodes in doc,
+// including additional array elements, are ignored.
+func AssertSubset(t testing.TB, doc, subset map[string]interface{}) bool {
+	t.Helper()
+
+	var diffs []voorhees.Difference
+	for _, d := range voorhees.Diff(subset, doc) {
+		if !d.MissingFromA {
+			diffs = append(diffs, d)
+		}
+	}
+
+	return reportDiffs(t, diffs)
+}
+
+// AssertJSONEqual asserts that expected and actual are equal, reporting the path of every node that differs.
+// Numbers are compared by value, regardless of their type, and any opts are applied as they are by voorhees.Equal.
+func AssertJSONEqual(t testing.TB, expected, actual map[string]interface{}, opts ...voorhees.EqualOption) bool {
+	t.Helper()
+
+	_, diffs := voorhees.Equal(expected, actual, opts...)
+
+	return reportDiffs(t, diffs)
+}
+
+// reportDiffs fails t with a line per difference, treating A as expected and B as actual.
+func reportDiffs(t testing.TB, diffs []voorhees.Difference) bool {
+	t.Helper()
+
+	if len(diffs) == 0 {
+		return true
+	}
+
+	lines := make([]string, len(diffs))
+	for i, d := range diffs {
+		lines[i] = "\t" + d.String()
+	}
+
+	t.Errorf("voorheestest: documents differ at %d path(s), as expected != actual:\n%s", len(diffs),
+		strings.Join(lines, "\n"))
+	return false
+}
+
+// appendPath appends a path relative to a node onto the path of that node.
+func appendPath(path, relative string) string {
+	switch {
+	case relative == "":
+		return path
+	case path == "" || strings.HasPrefix(relative, "["):
+		return path + relative
+	default:
+		return path + "." + relative
+	}
+}
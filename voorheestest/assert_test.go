@@ -0,0 +1,90 @@
+package voorheestest
+
+import (
+	"testing"
+
+	"github.com/sHesl/voorhees"
+	"github.com/stretchr/testify/assert"
+)
+
+var assertInput = map[string]interface{}{
+	"keepMe": "please",
+	"layer1": map[string]interface{}{
+		"array": []interface{}{
+			map[string]interface{}{
+				"count": 5,
+				"name":  "a",
+			},
+		},
+	},
+}
+
+func TestAssertPathEquals(t *testing.T) {
+	r := &recorder{}
+
+	assert.True(t, AssertPathEquals(r, assertInput, "layer1.array[0].count", 5))
+	assert.True(t, AssertPathEquals(r, assertInput, "layer1.array[0].count", float64(5)))
+	assert.True(t, AssertPathEquals(r, assertInput, "layer1.array", []map[string]interface{}{
+		map[string]interface{}{"count": 5, "name": "a"},
+	}))
+	assert.Empty(t, r.failures)
+
+	assert.False(t, AssertPathEquals(r, assertInput, "layer1.array[0]", map[string]interface{}{
+		"count": 6,
+		"name":  "a",
+	}))
+	assert.False(t, AssertPathEquals(r, assertInput, "layer1.uhoh", 5))
+	assert.Equal(t, []string{
+		"voorheestest: documents differ at 1 path(s), as expected != actual:\n\tlayer1.array[0].count: 6 != 5",
+		"voorheestest: layer1.uhoh: 5 != <missing>, because [Voorhees]: Unable to get uhoh because it doesn't exist at path layer1",
+	}, r.failures)
+}
+
+func TestAssertPathMissing(t *testing.T) {
+	r := &recorder{}
+
+	assert.True(t, AssertPathMissing(r, assertInput, "layer1.uhoh"))
+	assert.False(t, AssertPathMissing(r, assertInput, "keepMe"))
+	assert.Equal(t, []string{`voorheestest: keepMe: <missing> != "please", because keepMe should be missing`}, r.failures)
+}
+
+func TestAssertSubset(t *testing.T) {
+	r := &recorder{}
+
+	assert.True(t, AssertSubset(r, assertInput, map[string]interface{}{
+		"layer1": map[string]interface{}{
+			"array": []interface{}{
+				map[string]interface{}{"count": 5},
+			},
+		},
+	}))
+	assert.Empty(t, r.failures)
+
+	assert.False(t, AssertSubset(r, assertInput, map[string]interface{}{
+		"keepMe": "please",
+		"uhoh":   true,
+	}))
+	assert.Equal(t, []string{
+		"voorheestest: documents differ at 1 path(s), as expected != actual:\n\tuhoh: true != <missing>",
+	}, r.failures)
+}
+
+func TestAssertJSONEqual(t *testing.T) {
+	r := &recorder{}
+
+	assert.True(t, AssertJSONEqual(r, assertInput, New(t, assertInput).Add("keepMe", "please")))
+	assert.Empty(t, r.failures)
+
+	ignore, err := voorhees.IgnorePaths("layer1.array[*].name")
+	assert.NoError(t, err)
+
+	assert.True(t, AssertJSONEqual(r, assertInput, New(t, assertInput).Delete("layer1.array[0].name"), ignore))
+	assert.Empty(t, r.failures)
+
+	assert.False(t, AssertJSONEqual(r, assertInput, New(t, assertInput).Delete("layer1.array[0].name")))
+	assert.False(t, AssertJSONEqual(r, assertInput, New(t, assertInput).Add("added", []interface{}{"a"})))
+	assert.Equal(t, []string{
+		"voorheestest: documents differ at 1 path(s), as expected != actual:\n\tlayer1.array[0].name: \"a\" != <missing>",
+		"voorheestest: documents differ at 1 path(s), as expected != actual:\n\tadded: <missing> != [\"a\"]",
+	}, r.failures)
+}
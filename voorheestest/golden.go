@@ -0,0 +1,121 @@
+package voorheestest
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sHesl/voorhees"
+)
+
+// update is registered on the default FlagSet, so `go test -voorheestest.update` rewrites every golden file compared
+// by AssertGolden. The flag is namespaced so it never clashes with an update flag registered by the test package.
+var update = flag.Bool("voorheestest.update", false, "rewrite the golden files compared by voorheestest.AssertGolden")
+
+// Marshal serializes doc deterministically, so that results can be compared byte for byte. Map keys are sorted,
+// output is indented by two spaces, and numbers are formatted by value, so int(1), float64(1) and
+// json.Number("1.0") are all written as 1.
+func Marshal(doc interface{}) ([]byte, error) {
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+
+	var normalized interface{}
+	if err := d.Decode(&normalized); err != nil {
+		return nil, err
+	}
+
+	out, err := json.MarshalIndent(canonicalNumbers(normalized), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(out, '\n'), nil
+}
+
+func canonicalNumbers(x interface{}) interface{} {
+	switch node := x.(type) {
+	case map[string]interface{}:
+		for k, v := range node {
+			node[k] = canonicalNumbers(v)
+		}
+	case []interface{}:
+		for i, v := range node {
+			node[i] = canonicalNumbers(v)
+		}
+	case json.Number:
+		r, ok := new(big.Rat).SetString(string(node))
+		if !ok {
+			return node
+		}
+		if r.IsInt() {
+			return json.Number(r.Num().String())
+		}
+		f, _ := r.Float64()
+		return f
+	}
+
+	return x
+}
+
+// AssertGolden asserts that doc matches the golden file testdata/<name>.golden, reporting the path of every node
+// that differs. Running the tests with -voorheestest.update rewrites the golden file with doc instead.
+func AssertGolden(t testing.TB, name string, doc map[string]interface{}) bool {
+	t.Helper()
+
+	return assertGolden(t, filepath.Join("testdata", name+".golden"), doc, *update)
+}
+
+func assertGolden(t testing.TB, golden string, doc map[string]interface{}, update bool) bool {
+	t.Helper()
+
+	actual, err := Marshal(doc)
+	if err != nil {
+		t.Fatalf("voorheestest: unable to serialize document for %s: %s", golden, err)
+		return false
+	}
+
+	if update {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatalf("voorheestest: unable to update %s: %s", golden, err)
+			return false
+		}
+		if err := os.WriteFile(golden, actual, 0644); err != nil {
+			t.Fatalf("voorheestest: unable to update %s: %s", golden, err)
+			return false
+		}
+		return true
+	}
+
+	expected, err := os.ReadFile(golden)
+	if err != nil {
+		t.Errorf("voorheestest: unable to read %s, run with -voorheestest.update to create it: %s", golden, err)
+		return false
+	}
+
+	if bytes.Equal(expected, actual) {
+		return true
+	}
+
+	var expectedDoc map[string]interface{}
+	if err := json.Unmarshal(expected, &expectedDoc); err != nil {
+		t.Errorf("voorheestest: %s is not a valid golden file, run with -voorheestest.update to rewrite it: %s", golden, err)
+		return false
+	}
+
+	if !reportDiffs(t, voorhees.Diff(expectedDoc, doc)) {
+		return false
+	}
+
+	t.Errorf("voorheestest: %s is equal to the document but not formatted canonically, run with -voorheestest.update to "+
+		"rewrite it", golden)
+	return false
+}
@@ -0,0 +1,68 @@
+package voorheestest
+
+import (
+	"encoding/json"
+	"flag"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var goldenInput = map[string]interface{}{
+	"zebra":  json.Number("1.0"),
+	"apple":  int64(2),
+	"banana": 2.5,
+	"layer1": map[string]interface{}{
+		"array": []interface{}{"b", "a"},
+	},
+}
+
+func TestMarshal(t *testing.T) {
+	expected := `{
+  "apple": 2,
+  "banana": 2.5,
+  "layer1": {
+    "array": [
+      "b",
+      "a"
+    ]
+  },
+  "zebra": 1
+}
+`
+
+	result, err := Marshal(goldenInput)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, string(result))
+}
+
+func TestAssertGolden(t *testing.T) {
+	r := &recorder{}
+
+	assert.True(t, AssertGolden(r, "changed", New(t, goldenInput).Change("layer1.array[1]", "changed")))
+	assert.Empty(t, r.failures)
+
+	assert.False(t, AssertGolden(r, "changed", goldenInput))
+	assert.Equal(t, []string{
+		"voorheestest: documents differ at 1 path(s), as expected != actual:\n\tlayer1.array[1]: \"changed\" != \"a\"",
+	}, r.failures)
+}
+
+func TestUpdateFlagIsNamespaced(t *testing.T) {
+	assert.NotNil(t, flag.Lookup("voorheestest.update"))
+	assert.Nil(t, flag.Lookup("update"), "Expected test packages to remain free to register their own update flag")
+}
+
+func TestAssertGoldenUpdate(t *testing.T) {
+	golden := filepath.Join(t.TempDir(), "testdata", "updated.golden")
+	r := &recorder{}
+
+	assert.False(t, assertGolden(r, golden, goldenInput, false))
+	assert.Len(t, r.failures, 1)
+
+	assert.True(t, assertGolden(r, golden, goldenInput, true))
+	assert.True(t, assertGolden(r, golden, goldenInput, false))
+	assert.Len(t, r.failures, 1)
+}
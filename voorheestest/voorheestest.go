@@ -0,0 +1,101 @@
+// Package voorheestest provides helpers for building test cases with Voorhees, reporting failures through
+// testing.TB rather than returning errors or panicking.
+package voorheestest
+
+import (
+	"testing"
+
+	"github.com/sHesl/voorhees"
+)
+
+// Voorhees allows json path denoted manipulations of a map[string]interface{} inside tests.
+type Voorhees struct {
+	t testing.TB
+	v *voorhees.Voorhees
+}
+
+// New creates a new Voorhees instance bound to t, accepting the initial map[string]interface{} for manipulation.
+// Like voorhees.NewVoorhees, this preliminary value is deep copied so the original is never modified.
+// Unlike voorhees.PanickerVoorhees, a failing manipulation fails only the current test, reporting the
+// operation and path responsible.
+func New(t testing.TB, src map[string]interface{}) *Voorhees {
+	return &Voorhees{t, voorhees.NewVoorhees(src)}
+}
+
+// Get returns the value of the property denoted at the end of the provided JSON path,
+// behaving exactly as voorhees.Voorhees.Get(), except the test is failed upon encountering an error.
+func (tv *Voorhees) Get(path string) interface{} {
+	tv.t.Helper()
+
+	result, err := tv.v.Get(path)
+	if err != nil {
+		tv.t.Fatalf("voorheestest: Get(%q) failed: %s", path, err)
+	}
+
+	return result
+}
+
+// Add creates an addition property of the provided value at path,
+// behaving exactly as voorhees.Voorhees.Add(), except the test is failed upon encountering an error.
+func (tv *Voorhees) Add(path string, val interface{}) map[string]interface{} {
+	tv.t.Helper()
+
+	result, err := tv.v.Add(path, val)
+	if err != nil {
+		tv.t.Fatalf("voorheestest: Add(%q, %v) failed: %s", path, val, err)
+	}
+
+	return result
+}
+
+// Change replaces the property denoted at the end of the provided JSON path with the value provided,
+// behaving exactly as voorhees.Voorhees.Change(), except the test is failed upon encountering an error.
+func (tv *Voorhees) Change(path string, val interface{}) map[string]interface{} {
+	tv.t.Helper()
+
+	result, err := tv.v.Change(path, val)
+	if err != nil {
+		tv.t.Fatalf("voorheestest: Change(%q, %v) failed: %s", path, val, err)
+	}
+
+	return result
+}
+
+// ChangeSameType replaces the property denoted at the end of the provided JSON path with a value of the same kind,
+// behaving exactly as voorhees.Voorhees.ChangeSameType(), except the test is failed upon encountering an error.
+func (tv *Voorhees) ChangeSameType(path string, val interface{}, allow ...voorhees.Kind) map[string]interface{} {
+	tv.t.Helper()
+
+	result, err := tv.v.ChangeSameType(path, val, allow...)
+	if err != nil {
+		tv.t.Fatalf("voorheestest: ChangeSameType(%q, %v) failed: %s", path, val, err)
+	}
+
+	return result
+}
+
+// Update replaces the property denoted at the end of the provided JSON path with the value derived from it by fn,
+// behaving exactly as voorhees.Voorhees.Update(), except the test is failed upon encountering an error.
+func (tv *Voorhees) Update(path string, fn voorhees.Transform) map[string]interface{} {
+	tv.t.Helper()
+
+	result, err := tv.v.Update(path, fn)
+	if err != nil {
+		tv.t.Fatalf("voorheestest: Update(%q) failed: %s", path, err)
+	}
+
+	return result
+}
+
+// Delete removes the property denoted at the end of the provided JSON path,
+// behaving exactly as voorhees.Voorhees.Delete(), except the test is failed upon encountering an error.
+func (tv *Voorhees) Delete(path string) map[string]interface{} {
+	tv.t.Helper()
+
+	result, err := tv.v.Delete(path)
+	if err != nil {
+		tv.t.Fatalf("voorheestest: Delete(%q) failed: %s", path, err)
+	}
+
+	return result
+}
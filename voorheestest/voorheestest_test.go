@@ -0,0 +1,97 @@
+package voorheestest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sHesl/voorhees"
+	"github.com/stretchr/testify/assert"
+)
+
+// recorder captures failures reported through testing.TB, so that failing helpers can be tested without failing
+// the test that exercises them.
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestManipulations(t *testing.T) {
+	input := map[string]interface{}{
+		"keepMe": "please",
+		"layer1": map[string]interface{}{
+			"changeMe": "please",
+			"deleteMe": "please",
+		},
+	}
+
+	expected := map[string]interface{}{
+		"keepMe": "please",
+		"added":  "excellent",
+		"layer1": map[string]interface{}{
+			"changeMe": "changed",
+		},
+	}
+
+	tv := New(t, input)
+	tv.Add("added", "excellent")
+	tv.Change("layer1.changeMe", "changed")
+	result := tv.Delete("layer1.deleteMe")
+
+	assert.Equal(t, expected, result)
+	assert.Equal(t, "changed", tv.Get("layer1.changeMe"))
+	assert.NotEqual(t, expected, input)
+}
+
+func TestFailuresReportOperationAndPath(t *testing.T) {
+	type testCase struct {
+		manipulate func(tv *Voorhees)
+		expected   string
+	}
+
+	testCases := []testCase{
+		testCase{
+			func(tv *Voorhees) { tv.Get("layer1.uhoh") },
+			`voorheestest: Get("layer1.uhoh") failed: [Voorhees]: Unable to get uhoh because it doesn't exist at path layer1`,
+		},
+		testCase{
+			func(tv *Voorhees) { tv.Add("keepMe.uhoh.added", "x") },
+			`voorheestest: Add("keepMe.uhoh.added", x) failed: [Voorhees]: Unable to navigate to keepMe.uhoh. Node: keepMe was not a map[string]interface{}`,
+		},
+		testCase{
+			func(tv *Voorhees) { tv.Change("layer1.uhoh", "x") },
+			`voorheestest: Change("layer1.uhoh", x) failed: [Voorhees]: Unable to change uhoh because it doesn't exist at path layer1`,
+		},
+		testCase{
+			func(tv *Voorhees) { tv.ChangeSameType("keepMe", 5) },
+			`voorheestest: ChangeSameType("keepMe", 5) failed: [Voorhees]: Unable to change keepMe from string to number`,
+		},
+		testCase{
+			func(tv *Voorhees) { tv.Update("keepMe", voorhees.Toggle()) },
+			`voorheestest: Update("keepMe") failed: [Voorhees]: Unable to update keepMe. Toggle requires a boolean, not string`,
+		},
+		testCase{
+			func(tv *Voorhees) { tv.Delete("uhoh.deleteMe") },
+			`voorheestest: Delete("uhoh.deleteMe") failed: [Voorhees]: Unable to navigate to uhoh. Failed to find node: uhoh`,
+		},
+	}
+
+	for _, testCase := range testCases {
+		r := &recorder{}
+		testCase.manipulate(New(r, map[string]interface{}{
+			"keepMe": "please",
+			"layer1": map[string]interface{}{},
+		}))
+
+		assert.Equal(t, []string{testCase.expected}, r.failures)
+	}
+}
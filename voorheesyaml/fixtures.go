@@ -0,0 +1,239 @@
+// Package voorheesyaml reads Voorhees fixture files written in YAML, so that the voorhees package itself has no
+// dependencies beyond the standard library.
+package voorheesyaml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+
+	"github.com/sHesl/voorhees"
+	"gopkg.in/yaml.v3"
+)
+
+// LoadFixtures reads the YAML fixture file at filename. See ReadFixtures for the format of fixture files.
+func LoadFixtures(filename string) ([]voorhees.Fixture, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ReadFixtures(f, filename)
+}
+
+// ReadFixtures reads a YAML fixture file from r, applying each of its cases to a fresh copy of its base document.
+// Fixture files describe the same base document and list of named cases as voorhees.ReadFixtures reads from JSON:
+//
+//	base:
+//	  user: {name: jason, email: jason@crystal.lake}
+//	cases:
+//	  - name: without email
+//	    ops:
+//	      - {op: delete, path: user.email}
+//
+// Values are decoded as their JSON equivalents, so YAML fixtures produce exactly the same documents as JSON ones.
+// Cases that fail to apply are reported together as voorhees.FixtureErrors, each naming the file and line of the
+// failing operation, alongside the fixtures that were applied successfully. filename is used only for error messages.
+func ReadFixtures(r io.Reader, filename string) ([]voorhees.Fixture, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	base, cases, err := parse(data, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures []voorhees.Fixture
+	var errs voorhees.FixtureErrors
+
+	for _, c := range cases {
+		v := voorhees.NewVoorhees(base)
+		var failed *voorhees.FixtureError
+
+		for i, op := range c.ops {
+			if _, err := v.Apply(op); err != nil {
+				failed = &voorhees.FixtureError{File: filename, Line: c.opLines[i], Case: c.name, Err: err}
+				break
+			}
+		}
+
+		if failed != nil {
+			errs = append(errs, failed)
+			continue
+		}
+
+		fixtures = append(fixtures, voorhees.Fixture{Name: c.name, JSON: v.JSON})
+	}
+
+	if len(errs) > 0 {
+		return fixtures, errs
+	}
+
+	return fixtures, nil
+}
+
+type fixtureCase struct {
+	name    string
+	ops     []voorhees.Operation
+	opLines []int
+}
+
+// fixtureParser walks the nodes of a fixture file, which record the line of each operation.
+type fixtureParser struct {
+	file string
+}
+
+func parse(data []byte, filename string) (map[string]interface{}, []fixtureCase, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, syntaxError(filename, err)
+	}
+
+	root := &doc
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		root = doc.Content[0]
+	}
+
+	p := &fixtureParser{file: filename}
+	base := map[string]interface{}{}
+	var cases []fixtureCase
+
+	err := p.mapping(root, func(key string, value *yaml.Node) error {
+		switch key {
+		case "base":
+			return p.decode(value, &base)
+		case "cases":
+			return p.sequence(value, func(n *yaml.Node) error {
+				c, err := p.fixtureCase(n)
+				cases = append(cases, c)
+				return err
+			})
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return base, cases, nil
+}
+
+func (p *fixtureParser) fixtureCase(n *yaml.Node) (fixtureCase, error) {
+	var c fixtureCase
+
+	err := p.mapping(n, func(key string, value *yaml.Node) error {
+		switch key {
+		case "name":
+			return p.decode(value, &c.name)
+		case "ops":
+			return p.sequence(value, func(n *yaml.Node) error {
+				var op voorhees.Operation
+				c.opLines = append(c.opLines, n.Line)
+				err := p.decode(n, &op)
+				c.ops = append(c.ops, op)
+				return err
+			})
+		}
+
+		return nil
+	})
+
+	return c, err
+}
+
+func (p *fixtureParser) mapping(n *yaml.Node, field func(key string, value *yaml.Node) error) error {
+	if err := p.expect(n, yaml.MappingNode); err != nil {
+		return err
+	}
+
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		if err := field(n.Content[i].Value, n.Content[i+1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *fixtureParser) sequence(n *yaml.Node, elem func(n *yaml.Node) error) error {
+	if err := p.expect(n, yaml.SequenceNode); err != nil {
+		return err
+	}
+
+	for _, item := range n.Content {
+		if err := elem(item); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *fixtureParser) expect(n *yaml.Node, kind yaml.Kind) error {
+	if n.Kind != kind {
+		err := fmt.Errorf("expected %s but found %s", kinds[kind], kinds[n.Kind])
+		return &voorhees.FixtureError{File: p.file, Line: line(n), Err: err}
+	}
+
+	return nil
+}
+
+// decode decodes n into x by way of JSON, so that YAML fixtures produce exactly the same values as JSON fixtures.
+func (p *fixtureParser) decode(n *yaml.Node, x interface{}) error {
+	var val interface{}
+	err := n.Decode(&val)
+
+	var data []byte
+	if err == nil {
+		data, err = json.Marshal(val)
+	}
+	if err == nil {
+		err = json.Unmarshal(data, x)
+	}
+
+	if err != nil {
+		return &voorhees.FixtureError{File: p.file, Line: line(n), Err: err}
+	}
+
+	return nil
+}
+
+var kinds = map[yaml.Kind]string{
+	0:                 "nothing",
+	yaml.DocumentNode: "a document",
+	yaml.SequenceNode: "a sequence",
+	yaml.MappingNode:  "a mapping",
+	yaml.ScalarNode:   "a scalar",
+	yaml.AliasNode:    "an alias",
+}
+
+// line returns the line of n, or the first line for the empty node of an empty file.
+func line(n *yaml.Node) int {
+	if n.Line == 0 {
+		return 1
+	}
+
+	return n.Line
+}
+
+var errLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+
+// syntaxError moves the line reported by a YAML syntax error into a FixtureError.
+func syntaxError(filename string, err error) error {
+	match := errLine.FindStringSubmatch(err.Error())
+	if match == nil {
+		return &voorhees.FixtureError{File: filename, Line: 1, Err: err}
+	}
+
+	line, _ := strconv.Atoi(match[1])
+	return &voorhees.FixtureError{File: filename, Line: line, Err: errors.New(match[2])}
+}
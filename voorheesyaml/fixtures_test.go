@@ -0,0 +1,78 @@
+package voorheesyaml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sHesl/voorhees"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadFixtures(t *testing.T) {
+	expected := []voorhees.Fixture{
+		voorhees.Fixture{Name: "without email", JSON: map[string]interface{}{
+			"user": map[string]interface{}{
+				"name": "jason",
+				"tags": []interface{}{"camp"},
+			},
+		}},
+		voorhees.Fixture{Name: "renamed and tagged", JSON: map[string]interface{}{
+			"user": map[string]interface{}{
+				"name":  "pamela",
+				"email": "jason@crystal.lake",
+				"tags":  []interface{}{"camp", "mother"},
+			},
+		}},
+	}
+
+	fixtures, err := LoadFixtures("testdata/fixtures.yaml")
+
+	assert.Equal(t, expected, fixtures)
+	assert.Error(t, err)
+	assert.Equal(t, `testdata/fixtures.yaml:19: case "missing property": `+
+		`[Voorhees]: Unable to change uhoh because it doesn't exist at path user`+"\n"+
+		`testdata/fixtures.yaml:22: case "unknown operation": [Voorhees]: Unknown operation move for path user.name`,
+		err.Error())
+	assert.Len(t, err.(voorhees.FixtureErrors), 2)
+}
+
+func TestLoadFixturesMatchesJSON(t *testing.T) {
+	fromYAML, _ := LoadFixtures("testdata/fixtures.yaml")
+	fromJSON, _ := voorhees.LoadFixtures("../testdata/fixtures.json")
+
+	assert.Equal(t, fromJSON, fromYAML)
+}
+
+func TestReadFixturesInvalidYAML(t *testing.T) {
+	type testCase struct {
+		input    string
+		expected string
+	}
+
+	testCases := []testCase{
+		testCase{
+			"cases:\n  - name: a\n    ops:\n      - op: 1\n",
+			"fixtures.yaml:4: json: cannot unmarshal number into Go struct field Operation.op of type string",
+		},
+		testCase{
+			"base: {}\ncases: {}\n",
+			"fixtures.yaml:2: expected a sequence but found a mapping",
+		},
+		testCase{
+			"",
+			"fixtures.yaml:1: expected a mapping but found nothing",
+		},
+		testCase{
+			"base:\n  user: jason\n    name: pamela\n",
+			"fixtures.yaml:3: mapping values are not allowed in this context",
+		},
+	}
+
+	for _, testCase := range testCases {
+		fixtures, err := ReadFixtures(strings.NewReader(testCase.input), "fixtures.yaml")
+
+		assert.Nil(t, fixtures)
+		assert.Error(t, err)
+		assert.Equal(t, testCase.expected, err.Error())
+	}
+}
@@ -0,0 +1,98 @@
+package voorhees
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// WalkFunc is called by Walk for every node in the document. The path provided is always one that can be passed
+// straight back into Get, Add, Change or Delete.
+type WalkFunc func(path string, value interface{}) error
+
+// WalkOrder determines whether Walk visits a node before or after its children.
+type WalkOrder int
+
+const (
+	// PreOrder visits each node before any of its children.
+	PreOrder WalkOrder = iota
+	// PostOrder visits each node after all of its children.
+	PostOrder
+)
+
+// SkipNode can be returned from a WalkFunc during a PreOrder walk to skip the children of the current node.
+// It is ignored during a PostOrder walk, as the children have already been visited.
+var SkipNode = errors.New("[Voorhees]: skip this node")
+
+// Walk traverses every node of the document, calling fn with the path and value of each. Map keys are visited in
+// sorted order and array elements in index order, so walks are deterministic. Walk defaults to PreOrder.
+// Any error returned from fn, other than SkipNode, halts the walk and is returned.
+func (v *Voorhees) Walk(fn WalkFunc, order ...WalkOrder) error {
+	o := PreOrder
+	if len(order) > 0 {
+		o = order[0]
+	}
+
+	return walkChildren("", v.JSON, fn, o)
+}
+
+func walkNode(path string, val interface{}, fn WalkFunc, order WalkOrder) error {
+	if order == PreOrder {
+		if err := fn(path, val); err != nil {
+			if err == SkipNode {
+				return nil
+			}
+			return err
+		}
+	}
+
+	if err := walkChildren(path, val, fn, order); err != nil {
+		return err
+	}
+
+	if order == PostOrder {
+		if err := fn(path, val); err != nil && err != SkipNode {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkChildren(path string, val interface{}, fn WalkFunc, order WalkOrder) error {
+	if node, isMap := asMap(val); isMap {
+		keys := make([]string, 0, len(node))
+		for k := range node {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if err := walkNode(joinPath(path, k), node[k], fn, order); err != nil {
+				return err
+			}
+		}
+	} else if node, isArray := asArray(val); isArray {
+		for i, elem := range node {
+			if err := walkNode(indexPath(path, i), elem, fn, order); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// joinPath appends the map key prop to path, escaping it as required.
+func joinPath(path, prop string) string {
+	if path == "" {
+		return escapeProperty(prop)
+	}
+
+	return path + "." + escapeProperty(prop)
+}
+
+// indexPath appends the array index i to path.
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
@@ -0,0 +1,124 @@
+package voorhees
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var walkInput = map[string]interface{}{
+	"keepMe": "please",
+	"layer1": map[string]interface{}{
+		"array": []interface{}{
+			map[string]interface{}{
+				"prop": "excellent",
+			},
+			[]interface{}{"a"},
+		},
+	},
+}
+
+func TestWalk(t *testing.T) {
+	expected := []string{
+		"keepMe",
+		"layer1",
+		"layer1.array",
+		"layer1.array[0]",
+		"layer1.array[0].prop",
+		"layer1.array[1]",
+		"layer1.array[1][0]",
+	}
+
+	var visited []string
+	err := NewVoorhees(walkInput).Walk(func(path string, value interface{}) error {
+		visited = append(visited, path)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, visited)
+}
+
+func TestWalkPostOrder(t *testing.T) {
+	expected := []string{
+		"keepMe",
+		"layer1.array[0].prop",
+		"layer1.array[0]",
+		"layer1.array[1][0]",
+		"layer1.array[1]",
+		"layer1.array",
+		"layer1",
+	}
+
+	var visited []string
+	err := NewVoorhees(walkInput).Walk(func(path string, value interface{}) error {
+		visited = append(visited, path)
+		return nil
+	}, PostOrder)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, visited)
+}
+
+func TestWalkSkipNode(t *testing.T) {
+	expected := []string{
+		"keepMe",
+		"layer1",
+		"layer1.array",
+		"layer1.array[0]",
+		"layer1.array[1]",
+		"layer1.array[1][0]",
+	}
+
+	var visited []string
+	err := NewVoorhees(walkInput).Walk(func(path string, value interface{}) error {
+		visited = append(visited, path)
+		if _, isMap := value.(map[string]interface{}); isMap && path != "layer1" {
+			return SkipNode
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, visited)
+}
+
+func TestWalkHaltsOnError(t *testing.T) {
+	halt := errors.New("halt")
+
+	visits := 0
+	err := NewVoorhees(walkInput).Walk(func(path string, value interface{}) error {
+		visits++
+		return halt
+	})
+
+	assert.Equal(t, halt, err)
+	assert.Equal(t, 1, visits)
+}
+
+func TestWalkPathsAreAddressable(t *testing.T) {
+	input := map[string]interface{}{
+		"dotted.key": map[string]interface{}{
+			"bracketed[0]": []interface{}{"a"},
+			"back\\slash":  "b",
+		},
+		"": map[string]interface{}{
+			"": []interface{}{"empty"},
+		},
+	}
+
+	v := NewVoorhees(input)
+	err := v.Walk(func(path string, value interface{}) error {
+		got, err := v.Get(path)
+		assert.NoError(t, err, "Expected walked path %s to be addressable", path)
+		assert.Equal(t, value, got)
+
+		_, err = v.Change(path, value)
+		assert.NoError(t, err, "Expected walked path %s to be changeable", path)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, deepCopy(input), v.JSON)
+}
@@ -0,0 +1,78 @@
+package voorhees
+
+// Event describes a successful manipulation of a document, as delivered to the functions passed to Watch.
+// Old is the value at Path before the operation, and New the value after it; either is nil when there was no value.
+// Both are copies taken as the operation was performed, so are unaffected by any operations performed after it.
+type Event struct {
+	Op   string
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+type watcher struct {
+	pattern pathPattern
+	fn      func(Event)
+}
+
+// Watch calls fn with an Event for every successful operation that affects the nodes matched by pattern.
+// Patterns use the same syntax as paths, with * matching any property and [*] any array index. An operation
+// affects a pattern when it manipulates a matched node, any node within it, or any node containing it, so watching
+// db hears about db.primary.host, and watching db.*.host hears about db being deleted.
+// Events are delivered after each Add, Change and Delete, and after the whole of an Apply. Undo, Redo and Restore
+// are delivered as the operations they perform. The returned func stops any further events being delivered.
+func (v *Voorhees) Watch(pattern string, fn func(Event)) (func(), error) {
+	compiled, err := compilePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &watcher{pattern: compiled, fn: fn}
+	v.watchers = append(v.watchers, w)
+
+	return func() {
+		for i := range v.watchers {
+			if v.watchers[i] == w {
+				v.watchers = append(v.watchers[:i:i], v.watchers[i+1:]...)
+				return
+			}
+		}
+	}, nil
+}
+
+// queue records the Event of op having been performed, for delivery by notify.
+func (v *Voorhees) queue(op Operation, old interface{}) {
+	e := Event{Op: op.Op, Path: op.Path, Old: old}
+	if op.Op != OpDelete {
+		val, _ := v.get(op.Path)
+		e.New = clone(val) // later operations in the same Apply may modify val before e is delivered
+	}
+
+	v.pending = append(v.pending, e)
+}
+
+// notify delivers any queued events to the watchers interested in them.
+func (v *Voorhees) notify() {
+	v.flush()()
+}
+
+// flush takes any queued events, returning a func that delivers them, so they can be delivered after any locks
+// have been released.
+func (v *Voorhees) flush() func() {
+	if len(v.pending) == 0 {
+		return func() {}
+	}
+
+	events, watchers := v.pending, append([]*watcher{}, v.watchers...)
+	v.pending = nil
+
+	return func() {
+		for _, e := range events {
+			for _, w := range watchers {
+				if w.pattern.overlaps(e.Path) {
+					w.fn(e)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package voorhees
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWatch(t *testing.T) {
+	type testCase struct {
+		pattern  string
+		expected []Event
+	}
+
+	testCases := []testCase{
+		testCase{"db", []Event{
+			{OpChange, "db.primary.host", "10.0.0.1", "10.0.0.3"},
+			{OpAdd, "db.primary.user", nil, "admin"},
+			{OpDelete, "db.replica", map[string]interface{}{"host": "10.0.0.2", "port": 5432.0}, nil},
+		}},
+		testCase{"db.primary.host", []Event{
+			{OpChange, "db.primary.host", "10.0.0.1", "10.0.0.3"},
+		}},
+		testCase{"db.*.host", []Event{
+			{OpChange, "db.primary.host", "10.0.0.1", "10.0.0.3"},
+			{OpDelete, "db.replica", map[string]interface{}{"host": "10.0.0.2", "port": 5432.0}, nil},
+		}},
+		testCase{"cache.ttl", []Event{
+			{OpChange, "cache", map[string]interface{}{"ttl": 60.0}, "disabled"},
+		}},
+		testCase{"", []Event{
+			{OpChange, "db.primary.host", "10.0.0.1", "10.0.0.3"},
+			{OpAdd, "db.primary.user", nil, "admin"},
+			{OpDelete, "db.replica", map[string]interface{}{"host": "10.0.0.2", "port": 5432.0}, nil},
+			{OpChange, "cache", map[string]interface{}{"ttl": 60.0}, "disabled"},
+		}},
+		testCase{"queue", nil},
+	}
+
+	for _, testCase := range testCases {
+		v := NewVoorhees(watchInput)
+
+		var events []Event
+		_, err := v.Watch(testCase.pattern, func(e Event) { events = append(events, e) })
+		assert.NoError(t, err)
+
+		v.Change("db.primary.host", "10.0.0.3")
+		v.Add("db.primary.user", "admin")
+		v.Delete("db.replica")
+		v.Change("db.doesNotExist", "ignored")
+		v.Change("cache", "disabled")
+
+		assert.Equal(t, testCase.expected, events, "Unexpected events watching %s", testCase.pattern)
+	}
+}
+
+func TestWatchBatch(t *testing.T) {
+	v := NewVoorhees(watchInput)
+
+	var hosts []interface{}
+	v.Watch("db.*.host", func(e Event) {
+		replica, _ := v.Get("db.replica.host")
+		hosts = append(hosts, replica)
+	})
+
+	v.Apply(
+		Operation{OpChange, "db.primary.host", "10.0.0.3"},
+		Operation{OpChange, "db.replica.host", "10.0.0.4"},
+	)
+
+	assert.Equal(t, []interface{}{"10.0.0.4", "10.0.0.4"}, hosts, "Expected events to be delivered after the batch")
+}
+
+func TestWatchBatchValues(t *testing.T) {
+	v := NewVoorhees(watchInput)
+
+	var events []Event
+	v.Watch("cache", func(e Event) { events = append(events, e) })
+
+	v.Apply(
+		Operation{OpAdd, "cache.hosts", []interface{}{"a", "b"}},
+		Operation{Op: OpDelete, Path: "cache.hosts[0]"},
+		Operation{OpChange, "cache", map[string]interface{}{}},
+	)
+
+	assert.Equal(t, []Event{
+		Event{OpAdd, "cache.hosts", nil, []interface{}{"a", "b"}},
+		Event{OpDelete, "cache.hosts[0]", "a", nil},
+		Event{OpChange, "cache", map[string]interface{}{"ttl": 60.0, "hosts": []interface{}{"b"}}, map[string]interface{}{}},
+	}, events, "Expected each event to hold the values as they were when its operation was performed")
+}
+
+func TestWatchCancel(t *testing.T) {
+	v := NewVoorhees(watchInput)
+
+	calls := 0
+	cancel, _ := v.Watch("db", func(e Event) { calls++ })
+	other, _ := v.Watch("db", func(e Event) {})
+
+	v.Change("db.primary.host", "10.0.0.3")
+	cancel()
+	v.Change("db.primary.host", "10.0.0.4")
+
+	assert.Equal(t, 1, calls)
+	assert.Len(t, v.watchers, 1)
+
+	other()
+	assert.Empty(t, v.watchers)
+}
+
+func TestWatchUndo(t *testing.T) {
+	v := NewVoorhees(watchInput, RecordHistory())
+
+	var events []Event
+	v.Watch("db.primary.host", func(e Event) { events = append(events, e) })
+
+	v.Change("db.primary.host", "10.0.0.3")
+	v.Undo()
+
+	assert.Equal(t, []Event{
+		{OpChange, "db.primary.host", "10.0.0.1", "10.0.0.3"},
+		{OpChange, "db.primary.host", "10.0.0.3", "10.0.0.1"},
+	}, events)
+}
+
+func TestWatchInvalidPattern(t *testing.T) {
+	_, err := NewVoorhees(watchInput).Watch("db[x]", func(e Event) {})
+
+	assert.Error(t, err)
+}
+
+func TestSyncVoorheesWatch(t *testing.T) {
+	s := NewSyncVoorhees(copyInput(watchInput))
+
+	var events []Event
+	var hosts []interface{}
+	s.Watch("db.primary", func(e Event) {
+		events = append(events, e)
+		host, _ := s.Get("db.primary.host") // must not deadlock
+		hosts = append(hosts, host)
+	})
+
+	_, err := s.Apply(
+		Operation{OpChange, "db.primary.host", "10.0.0.3"},
+		Operation{OpChange, "db.doesNotExist", "fails"},
+	)
+	assert.Error(t, err)
+	assert.Empty(t, events, "Expected no events from a batch that was not applied")
+
+	s.Change("db.primary.host", "10.0.0.3")
+
+	assert.Equal(t, []Event{{OpChange, "db.primary.host", "10.0.0.1", "10.0.0.3"}}, events)
+	assert.Equal(t, []interface{}{"10.0.0.3"}, hosts)
+}
+
+func TestSyncVoorheesWatchConcurrently(t *testing.T) {
+	s := NewSyncVoorhees(copyInput(watchInput))
+
+	var events []Event
+	s.Watch("db.primary.host", func(e Event) {
+		time.Sleep(time.Millisecond) // gives the next write time to take the lock
+		s.Get("db.primary.host")     // must not deadlock with a write waiting to deliver its own events
+		events = append(events, e)
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				s.Change("db.primary.host", float64(i))
+			}(i)
+		}
+		wg.Wait()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected concurrent writes with a watcher reading the document not to deadlock")
+	}
+
+	assert.Len(t, events, 20)
+	for i := 1; i < len(events); i++ {
+		assert.Equal(t, events[i-1].New, events[i].Old, "Expected events to be delivered in the order applied")
+	}
+}
+
+func TestSyncVoorheesWatcherManipulates(t *testing.T) {
+	s := NewSyncVoorhees(copyInput(watchInput))
+
+	var paths []string
+	s.Watch("", func(e Event) {
+		paths = append(paths, e.Path)
+		if e.Path == "db.primary.host" {
+			s.Add("db.primary.updated", true) // delivered once this watcher returns
+		}
+	})
+
+	s.Change("db.primary.host", "10.0.0.3")
+
+	updated, _ := s.Get("db.primary.updated")
+	assert.Equal(t, true, updated)
+	assert.Equal(t, []string{"db.primary.host", "db.primary.updated"}, paths)
+}